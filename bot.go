@@ -24,6 +24,13 @@ type Bot struct {
 	Proxy *url.URL
 	// Debug if set to true, every time Listener receives something, it will be printed.
 	Debug bool
+	// if set to true, callback queries that are not answered by Handler (with CallbackQuery.Answer or
+	// AnswerCallbackQueryData) are answered with no text after Handler returns, so the client doesn't keep
+	// showing a progress bar.
+	AutoAnswerCallbackQuery bool
+	// Server is the url of the Bot API server, like http://localhost:8081 for a local Bot API server.
+	// If empty, DefaultServer is used.
+	Server string
 }
 
 func (b Bot) ActivateProxy() error {
@@ -69,10 +76,16 @@ func (b Bot) webhookHandler(_ http.ResponseWriter, r *http.Request) {
 	if b.Handler == nil {
 		log.Println("Warning: Listener just received something, but you have not added a handler to bot." +
 			"add handler to bot by setting bot's Handler field to a function of type func(message Update, bot Bot)")
-	} else if b.Concurrent {
-		go b.Handler(*update, b)
 		return
+	}
+	handle := func() { b.Handler(*update, b) }
+	if b.AutoAnswerCallbackQuery && update.CallbackQuery.Id != "" {
+		handler := handle
+		handle = func() { b.handleCallbackQuery(update.CallbackQuery, handler) }
+	}
+	if b.Concurrent {
+		go handle()
 	} else {
-		b.Handler(*update, b)
+		handle()
 	}
 }
@@ -0,0 +1,282 @@
+package gogram
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCallbackSignature is returned by CallbackCodec.Decode when the signature of callback data is missing or
+// wrong, which means the callback data was not created by the bot.
+var ErrCallbackSignature = errors.New("signature of callback data is invalid")
+
+// CallbackStorage stores callback data that doesn't fit in 64 bytes. Implement it with a database if
+// buttons must keep working after the bot restarts.
+type CallbackStorage interface {
+	// Store saves data and returns a short key (at most 32 bytes) to load it.
+	Store(data string) (key string, err error)
+	// Load returns the data saved with key.
+	Load(key string) (data string, err error)
+}
+
+// DefaultCallbackDataTTL is how long MemoryCallbackStorage keeps data if TTL is not set.
+const DefaultCallbackDataTTL = 24 * time.Hour
+
+// MemoryCallbackStorage is a CallbackStorage which keeps data in memory. Data is removed TTL after it is last
+// stored, so memory doesn't grow without limit, and buttons with its key stop working then. Use
+// NewMemoryCallbackStorage to create one.
+type MemoryCallbackStorage struct {
+	// TTL is how long data is kept after it is stored. Default is DefaultCallbackDataTTL.
+	TTL   time.Duration
+	mu    sync.RWMutex
+	items map[string]storedCallbackData
+}
+
+type storedCallbackData struct {
+	data    string
+	expires time.Time
+}
+
+func NewMemoryCallbackStorage(ttl time.Duration) *MemoryCallbackStorage {
+	return &MemoryCallbackStorage{TTL: ttl, items: map[string]storedCallbackData{}}
+}
+
+// Store saves data with a key derived from its hash, so storing the same data twice returns the same key.
+// Storing data again keeps it for another TTL.
+func (m *MemoryCallbackStorage) Store(data string) (string, error) {
+	sum := sha256.Sum256([]byte(data))
+	key := base64.RawURLEncoding.EncodeToString(sum[:12])
+	ttl := m.TTL
+	if ttl == 0 {
+		ttl = DefaultCallbackDataTTL
+	}
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.items == nil {
+		m.items = map[string]storedCallbackData{}
+	}
+	for k, item := range m.items {
+		if now.After(item.expires) {
+			delete(m.items, k)
+		}
+	}
+	m.items[key] = storedCallbackData{data: data, expires: now.Add(ttl)}
+	return key, nil
+}
+
+func (m *MemoryCallbackStorage) Load(key string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	item, ok := m.items[key]
+	if !ok || time.Now().After(item.expires) {
+		return "", errors.New("callback data " + key + " is not found in storage")
+	}
+	return item.data, nil
+}
+
+// CallbackCodec encodes structs into callback data of inline buttons, and decodes callback data of callback
+// queries back into structs. Exported fields of a struct are encoded in order, separated by "|", after a
+// prefix that tells handlers which struct to decode:
+//
+//	type Vote struct {
+//		PollId int
+//		Option string
+//	}
+//	data, _ := codec.Encode("vote", Vote{7, "yes"}) // "vote|7|yes"
+//	if gogram.CallbackPrefix(q.Data) == "vote" {
+//		var v Vote
+//		err := codec.Decode(q.Data, &v)
+//	}
+//
+// Fields can be strings, bools, ints, uints and floats.
+type CallbackCodec struct {
+	// Key signs callback data with HMAC-SHA256 if it is set, so Decode rejects callback data that is not
+	// created by the bot. A signature takes 12 bytes of callback data.
+	Key []byte
+	// Storage stores callback data longer than 64 bytes, which is then replaced by a key to it.
+	// If it is nil, Encode returns an error for long callback data.
+	Storage CallbackStorage
+}
+
+// CallbackPrefix returns the prefix of callback data created by CallbackCodec.Encode.
+func CallbackPrefix(data string) string {
+	if i := strings.IndexAny(data, "|~"); i != -1 {
+		return data[:i]
+	}
+	return data
+}
+
+// Encode encodes v, a struct or a pointer to a struct, into callback data that starts with prefix.
+// prefix must not contain "|" or "~".
+func (c CallbackCodec) Encode(prefix string, v any) (string, error) {
+	if prefix == "" || strings.ContainsAny(prefix, "|~") {
+		return "", errors.New("prefix of callback data must be set and must not contain | or ~")
+	}
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Struct {
+		return "", errors.New("only structs can be encoded into callback data")
+	}
+	var data strings.Builder
+	data.WriteString(prefix)
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+		field, err := encodeCallbackField(value.Field(i))
+		if err != nil {
+			return "", errors.New(value.Type().Field(i).Name + ": " + err.Error())
+		}
+		data.WriteString("|" + field)
+	}
+	if c.Key != nil {
+		data.WriteString("|" + c.signature(data.String()))
+	}
+	if data.Len() <= MaxCallbackDataLength {
+		return data.String(), nil
+	}
+	if c.Storage == nil {
+		return "", errors.New("callback data is longer than 64 bytes and Storage of CallbackCodec is not set")
+	}
+	key, err := c.Storage.Store(data.String())
+	if err != nil {
+		return "", err
+	}
+	if stored := prefix + "~" + key; len(stored) <= MaxCallbackDataLength {
+		return stored, nil
+	}
+	return "", errors.New("key of stored callback data is too long")
+}
+
+// Decode decodes callback data created by Encode into v, which must be a pointer to a struct of the same type
+// that was encoded.
+func (c CallbackCodec) Decode(data string, v any) error {
+	prefix := CallbackPrefix(data)
+	if strings.HasPrefix(data, prefix+"~") {
+		if c.Storage == nil {
+			return errors.New("callback data is stored, but Storage of CallbackCodec is not set")
+		}
+		var err error
+		if data, err = c.Storage.Load(data[len(prefix)+1:]); err != nil {
+			return err
+		}
+	}
+	if c.Key != nil {
+		i := strings.LastIndex(data, "|")
+		if i == -1 || !hmac.Equal([]byte(data[i+1:]), []byte(c.signature(data[:i]))) {
+			return ErrCallbackSignature
+		}
+		data = data[:i]
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return errors.New("callback data can only be decoded into a pointer to a struct")
+	}
+	value = value.Elem()
+	fields := splitCallbackData(strings.TrimPrefix(data, prefix))
+	j := 0
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+		if j == len(fields) {
+			return errors.New("callback data has fewer fields than " + value.Type().Name())
+		}
+		if err := decodeCallbackField(fields[j], value.Field(i)); err != nil {
+			return errors.New(value.Type().Field(i).Name + ": " + err.Error())
+		}
+		j++
+	}
+	if j != len(fields) {
+		return errors.New("callback data has more fields than " + value.Type().Name())
+	}
+	return nil
+}
+
+func (c CallbackCodec) signature(data string) string {
+	mac := hmac.New(sha256.New, c.Key)
+	mac.Write([]byte(data))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// callbackEscaper escapes separators in string fields.
+var callbackEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+
+func encodeCallbackField(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return callbackEscaper.Replace(v.String()), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return "1", nil
+		}
+		return "0", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	}
+	return "", errors.New("type " + v.Type().String() + " can't be encoded into callback data")
+}
+
+func decodeCallbackField(s string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		v.SetBool(s == "1")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return errors.New("type " + v.Type().String() + " can't be decoded from callback data")
+	}
+	return nil
+}
+
+// splitCallbackData splits "|a|b\|c" into unescaped fields "a" and "b|c".
+func splitCallbackData(data string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(data); i++ {
+		switch {
+		case data[i] == '\\' && i+1 < len(data):
+			i++
+			field.WriteByte(data[i])
+		case data[i] == '|':
+			if i != 0 {
+				fields = append(fields, field.String())
+			}
+			field.Reset()
+		default:
+			field.WriteByte(data[i])
+		}
+	}
+	if len(data) != 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
@@ -0,0 +1,85 @@
+package gogram
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testVote struct {
+	PollId  int
+	Option  string
+	Public  bool
+	comment string
+}
+
+func TestCallbackCodec(t *testing.T) {
+	codec := CallbackCodec{}
+	data, err := codec.Encode("vote", testVote{PollId: 7, Option: `a|b\c`, Public: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data != `vote|7|a\|b\\c|1` {
+		t.Errorf("unexpected callback data %q", data)
+	}
+	var v testVote
+	if err = codec.Decode(data, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v != (testVote{PollId: 7, Option: `a|b\c`, Public: true}) {
+		t.Errorf("unexpected decoded value %+v", v)
+	}
+	if CallbackPrefix(data) != "vote" {
+		t.Errorf("unexpected prefix %q", CallbackPrefix(data))
+	}
+}
+
+func TestCallbackCodec_SignedAndStored(t *testing.T) {
+	codec := CallbackCodec{Key: []byte("secret"), Storage: NewMemoryCallbackStorage(0)}
+	short, err := codec.Encode("vote", testVote{PollId: 1, Option: "y"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v testVote
+	forged := strings.Replace(short, "vote|1|", "vote|2|", 1)
+	if err = codec.Decode(forged, &v); !errors.Is(err, ErrCallbackSignature) {
+		t.Errorf("forged callback data is accepted: %v", err)
+	}
+	long := testVote{PollId: 1, Option: strings.Repeat("o", 100)}
+	data, err := codec.Encode("vote", long)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > MaxCallbackDataLength || CallbackPrefix(data) != "vote" {
+		t.Errorf("unexpected stored callback data %q", data)
+	}
+	if err = codec.Decode(data, &v); err != nil || v != long {
+		t.Errorf("unexpected decoded value %+v, %v", v, err)
+	}
+	if _, err = (CallbackCodec{}).Encode("vote", long); err == nil {
+		t.Error("long callback data is accepted without a storage")
+	}
+}
+
+func TestMemoryCallbackStorage_TTL(t *testing.T) {
+	storage := NewMemoryCallbackStorage(20 * time.Millisecond)
+	old, err := storage.Store("old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if _, err = storage.Load(old); err == nil {
+		t.Error("expired data is loaded")
+	}
+	key, err := storage.Store("new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := storage.Load(key); err != nil || data != "new" {
+		t.Errorf("got %q, %v", data, err)
+	}
+	if len(storage.items) != 1 {
+		t.Errorf("expired data is kept: %v", storage.items)
+	}
+}
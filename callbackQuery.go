@@ -0,0 +1,72 @@
+package gogram
+
+import "sync"
+
+// pendingCallbackQueries holds ids of callback queries that must be answered automatically
+// (see Bot.AutoAnswerCallbackQuery), with whether they are answered by the handler.
+var (
+	pendingCallbackQueries   = map[string]bool{}
+	pendingCallbackQueriesMu sync.Mutex
+)
+
+// markAnswered records that a pending callback query is answered.
+func markAnswered(callbackQueryId string) {
+	pendingCallbackQueriesMu.Lock()
+	defer pendingCallbackQueriesMu.Unlock()
+	if _, ok := pendingCallbackQueries[callbackQueryId]; ok {
+		pendingCallbackQueries[callbackQueryId] = true
+	}
+}
+
+// handleCallbackQuery calls handle, and answers callback query c afterwards if handle doesn't.
+func (b Bot) handleCallbackQuery(c CallbackQuery, handle func()) {
+	pendingCallbackQueriesMu.Lock()
+	pendingCallbackQueries[c.Id] = false
+	pendingCallbackQueriesMu.Unlock()
+	defer func() {
+		pendingCallbackQueriesMu.Lock()
+		answered := pendingCallbackQueries[c.Id]
+		delete(pendingCallbackQueries, c.Id)
+		pendingCallbackQueriesMu.Unlock()
+		if !answered {
+			_, _ = c.Answer(b, AnswerCallbackQueryData{})
+		}
+	}()
+	handle()
+}
+
+// Answer answers the callback query. CallbackQueryId of data is set automatically.
+func (c CallbackQuery) Answer(b Bot, data AnswerCallbackQueryData) (Response, error) {
+	data.CallbackQueryId = c.Id
+	return data.Send(b)
+}
+
+// EditText edits the text of the message of the callback query. ChatId and MessageId, or InlineMessageId
+// of data are set automatically.
+func (c CallbackQuery) EditText(b Bot, data EditMessageTextData) (Response, error) {
+	data.ChatId, data.MessageId, data.InlineMessageId = c.messageIds()
+	return data.Send(b)
+}
+
+// EditCaption edits the caption of the message of the callback query. ChatId and MessageId, or
+// InlineMessageId of data are set automatically.
+func (c CallbackQuery) EditCaption(b Bot, data EditMessageCaptionData) (Response, error) {
+	data.ChatId, data.MessageId, data.InlineMessageId = c.messageIds()
+	return data.Send(b)
+}
+
+// EditReplyMarkup replaces the inline keyboard of the message of the callback query.
+func (c CallbackQuery) EditReplyMarkup(b Bot, k InlineKeyboard) (Response, error) {
+	data := EditMessageReplyMarkupData{InlineKeyboard: k}
+	data.ChatId, data.MessageId, data.InlineMessageId = c.messageIds()
+	return data.Send(b)
+}
+
+// messageIds returns the ids that edit data need to target the message of the callback query: either
+// the chat and message ids, or the inline message id for messages sent in inline mode.
+func (c CallbackQuery) messageIds() (chatId, messageId int, inlineMessageId string) {
+	if c.InlineMessageId != "" {
+		return 0, 0, c.InlineMessageId
+	}
+	return c.Message.Chat.Id, c.Message.MessageId, ""
+}
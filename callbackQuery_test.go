@@ -0,0 +1,46 @@
+package gogram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBot_AutoAnswerCallbackQuery(t *testing.T) {
+	var mu sync.Mutex
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	update := `{"update_id":1,"callback_query":{"id":"7","from":{"id":5},"data":"d",` +
+		`"message":{"message_id":2,"chat":{"id":3}}}}`
+
+	var from int
+	b := Bot{Token: "token", Server: server.URL, AutoAnswerCallbackQuery: true,
+		Handler: func(u Update, b Bot) { from = u.CallbackQuery.From.Id }}
+	b.webhookHandler(nil, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(update)))
+	if from != 5 {
+		t.Errorf("unexpected sender %d", from)
+	}
+	if want := []string{"answerCallbackQuery"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("got %v, want %v", methods, want)
+	}
+
+	// a callback query answered by the handler is not answered again
+	methods = nil
+	b.Handler = func(u Update, b Bot) {
+		_, _ = u.CallbackQuery.EditText(b, EditMessageTextData{Text: "t"})
+		_, _ = u.CallbackQuery.Answer(b, AnswerCallbackQueryData{Text: "done"})
+	}
+	b.webhookHandler(nil, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(update)))
+	if want := []string{"editMessageText", "answerCallbackQuery"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("got %v, want %v", methods, want)
+	}
+}
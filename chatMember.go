@@ -0,0 +1,154 @@
+package gogram
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ChatMember is one of ChatMemberOwner, ChatMemberAdministrator, ChatMemberMember, ChatMemberRestricted,
+// ChatMemberLeft and ChatMemberBanned.
+type ChatMember interface {
+	GetStatus() string
+	GetUser() User
+}
+
+func (c ChatMemberOwner) GetStatus() string         { return c.Status }
+func (c ChatMemberOwner) GetUser() User             { return c.User }
+func (c ChatMemberAdministrator) GetStatus() string { return c.Status }
+func (c ChatMemberAdministrator) GetUser() User     { return c.User }
+func (c ChatMemberMember) GetStatus() string        { return c.Status }
+func (c ChatMemberMember) GetUser() User            { return c.User }
+func (c ChatMemberRestricted) GetStatus() string    { return c.Status }
+func (c ChatMemberRestricted) GetUser() User        { return c.User }
+func (c ChatMemberLeft) GetStatus() string          { return c.Status }
+func (c ChatMemberLeft) GetUser() User              { return c.User }
+func (c ChatMemberBanned) GetStatus() string        { return c.Status }
+func (c ChatMemberBanned) GetUser() User            { return c.User }
+
+// Member holds a ChatMember of the type its status tells. It is returned by GetChatMemberData and
+// GetChatAdministratorsData; use a type switch on its ChatMember to read the fields of a status, or its
+// helpers to check permissions.
+type Member struct {
+	ChatMember
+}
+
+// UnmarshalJSON decodes a ChatMember by its status.
+func (m *Member) UnmarshalJSON(data []byte) error {
+	var status struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return err
+	}
+	var member ChatMember
+	var err error
+	switch status.Status {
+	case "creator":
+		var c ChatMemberOwner
+		err = json.Unmarshal(data, &c)
+		member = c
+	case "administrator":
+		var c ChatMemberAdministrator
+		err = json.Unmarshal(data, &c)
+		member = c
+	case "member":
+		var c ChatMemberMember
+		err = json.Unmarshal(data, &c)
+		member = c
+	case "restricted":
+		var c ChatMemberRestricted
+		err = json.Unmarshal(data, &c)
+		member = c
+	case "left":
+		var c ChatMemberLeft
+		err = json.Unmarshal(data, &c)
+		member = c
+	case "kicked":
+		var c ChatMemberBanned
+		err = json.Unmarshal(data, &c)
+		member = c
+	default:
+		return errors.New("unknown status of chat member: " + status.Status)
+	}
+	m.ChatMember = member
+	return err
+}
+
+// MarshalJSON encodes the ChatMember of m.
+func (m Member) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.ChatMember)
+}
+
+// IsOwner reports whether the member is the owner of the chat.
+func (m Member) IsOwner() bool {
+	_, ok := m.ChatMember.(ChatMemberOwner)
+	return ok
+}
+
+// IsAdmin reports whether the member is an administrator or the owner of the chat.
+func (m Member) IsAdmin() bool {
+	_, ok := m.ChatMember.(ChatMemberAdministrator)
+	return ok || m.IsOwner()
+}
+
+// IsMember reports whether the user is in the chat, including restricted members that are in the chat.
+func (m Member) IsMember() bool {
+	switch c := m.ChatMember.(type) {
+	case ChatMemberOwner, ChatMemberAdministrator, ChatMemberMember:
+		return true
+	case ChatMemberRestricted:
+		return c.IsMember
+	}
+	return false
+}
+
+// IsBanned reports whether the member is banned from the chat.
+func (m Member) IsBanned() bool {
+	_, ok := m.ChatMember.(ChatMemberBanned)
+	return ok
+}
+
+// CanDeleteMessages reports whether the member can delete messages of other users. The owner always can;
+// administrators can if they are granted the right.
+func (m Member) CanDeleteMessages() bool {
+	return m.IsOwner() || m.admin().CanDeleteMessages
+}
+
+// CanRestrictMembers reports whether the member can restrict, ban and unban members. The owner always can;
+// administrators can if they are granted the right.
+func (m Member) CanRestrictMembers() bool {
+	return m.IsOwner() || m.admin().CanRestrictMembers
+}
+
+// CanPromoteMembers reports whether the member can add administrators. The owner always can; administrators
+// can if they are granted the right.
+func (m Member) CanPromoteMembers() bool {
+	return m.IsOwner() || m.admin().CanPromoteMembers
+}
+
+// CanChangeInfo reports whether the member can change the title, photo and other settings of the chat. It is
+// false for members, who can if the permissions of the chat allow it, which are not known here.
+func (m Member) CanChangeInfo() bool {
+	r, ok := m.ChatMember.(ChatMemberRestricted)
+	return m.IsOwner() || m.admin().CanChangeInfo || ok && r.CanChangeInfo
+}
+
+// CanInviteUsers reports whether the member can invite users to the chat. It is false for members, who can if
+// the permissions of the chat allow it, which are not known here.
+func (m Member) CanInviteUsers() bool {
+	r, ok := m.ChatMember.(ChatMemberRestricted)
+	return m.IsOwner() || m.admin().CanInviteUsers || ok && r.CanInviteUsers
+}
+
+// CanPinMessages reports whether the member can pin messages. It is false for members, who can if the
+// permissions of the chat allow it, which are not known here.
+func (m Member) CanPinMessages() bool {
+	r, ok := m.ChatMember.(ChatMemberRestricted)
+	return m.IsOwner() || m.admin().CanPinMessages || ok && r.CanPinMessages
+}
+
+// admin returns the member as an administrator, with no rights if it is not one.
+func (m Member) admin() ChatMemberAdministrator {
+	a, _ := m.ChatMember.(ChatMemberAdministrator)
+	return a
+}
@@ -0,0 +1,52 @@
+package gogram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMember_UnmarshalJSON(t *testing.T) {
+	var members []Member
+	err := json.Unmarshal([]byte(`[{"status":"creator","user":{"id":1}},
+		{"status":"administrator","user":{"id":2},"can_delete_messages":true},
+		{"status":"restricted","user":{"id":3},"is_member":true,"can_pin_messages":true,"until_date":1700000000},
+		{"status":"kicked","user":{"id":4},"until_date":0},
+		{"status":"left","user":{"id":5}}]`), &members)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range []struct {
+		status                             string
+		admin, member, banned, del, pinned bool
+	}{{"creator", true, true, false, true, true}, {"administrator", true, true, false, true, false},
+		{"restricted", false, true, false, false, true}, {"kicked", false, false, true, false, false},
+		{"left", false, false, false, false, false}} {
+		m := members[i]
+		if m.GetStatus() != c.status || m.GetUser().Id != i+1 || m.IsAdmin() != c.admin || m.IsMember() != c.member ||
+			m.IsBanned() != c.banned || m.CanDeleteMessages() != c.del || m.CanPinMessages() != c.pinned {
+			t.Errorf("unexpected member %d: %+v", i, m.ChatMember)
+		}
+	}
+	if r := members[2].ChatMember.(ChatMemberRestricted); r.UntilDate != 1700000000 {
+		t.Errorf("unexpected until date %d", r.UntilDate)
+	}
+	if err = json.Unmarshal([]byte(`{"status":"unknown"}`), &Member{}); err == nil {
+		t.Error("unknown status is decoded")
+	}
+}
+
+func TestGetChatAdministratorsData_TypedResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true,"result":[{"status":"creator","user":{"id":1}}]}`))
+	}))
+	defer server.Close()
+	res, err := GetChatAdministratorsData{ChatId: 1}.Send(Bot{Token: "token", Server: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if admins := *res.getResult().(*[]Member); len(admins) != 1 || !admins[0].IsOwner() {
+		t.Errorf("unexpected administrators %v", admins)
+	}
+}
@@ -3,6 +3,7 @@ package gogram
 import (
 	"errors"
 	"os"
+	"strconv"
 )
 
 type Method interface {
@@ -14,14 +15,14 @@ type Method interface {
 
 // TextData sends text messages. On success, the sent Message is returned.
 type TextData struct {
-	Text                     string          `json:"text"`
-	ChatId                   int             `json:"chat_id"`
-	ParseMode                string          `json:"parse_mode"`
-	Entities                 []MessageEntity `json:"entities"`
-	DisableWebPagePreview    bool            `json:"disable_web_page_preview"`
-	DisableNotification      bool            `json:"disable_notification"`
-	ReplyToMessageId         int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply"`
+	Text                     string          `json:"text" check:"required"`
+	ChatId                   int             `json:"chat_id" check:"required"`
+	ParseMode                string          `json:"parse_mode,omitempty"`
+	Entities                 []MessageEntity `json:"entities,omitempty"`
+	DisableWebPagePreview    bool            `json:"disable_web_page_preview,omitempty"`
+	DisableNotification      bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -30,7 +31,10 @@ func (t TextData) Send(b Bot) (Response, error) {
 }
 
 func (t TextData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Text": t.Text, "ChatId": t.ChatId, "ParseMode": t.ParseMode})
+	val := newValidator(t)
+	val.textLength("Text", t.Text, t.ParseMode, 1, MaxTextLength)
+	val.replyMarkup("ReplyMarkup", t.ReplyMarkup)
+	return val.err()
 }
 
 // PhotoData sends photos. On success, the sent Message is returned.
@@ -40,14 +44,14 @@ type PhotoData struct {
 	// video using os.Open(<file_name>). The photo must be at most 10 MB in size.
 	// The photo's width and height must not exceed 10000 in total.
 	// Width and height ratio must be at most 20.
-	Photo                    any             `json:"photo"`
-	ChatId                   int             `json:"chat_id"`
-	ParseMode                string          `json:"parse_mode"`
-	Caption                  string          `json:"caption"`
-	CaptionEntities          []MessageEntity `json:"caption_entities"`
-	DisableNotification      bool            `json:"disable_notification"`
-	ReplyToMessageId         int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply"`
+	Photo                    any             `json:"photo" check:"required"`
+	ChatId                   int             `json:"chat_id" check:"required"`
+	ParseMode                string          `json:"parse_mode,omitempty"`
+	Caption                  string          `json:"caption,omitempty"`
+	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
+	DisableNotification      bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -56,28 +60,31 @@ func (p PhotoData) Send(b Bot) (Response, error) {
 }
 
 func (p PhotoData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Photo": p.Photo, "ChatId": p.ChatId, "ParseMode": p.ParseMode})
+	val := newValidator(p)
+	val.textLength("Caption", p.Caption, p.ParseMode, 0, MaxCaptionLength)
+	val.replyMarkup("ReplyMarkup", p.ReplyMarkup)
+	return val.err()
 }
 
 // VideoData sends video files, Telegram clients support mp4 videos (other formats may be sent as Document).
 // On success, the sent Message is returned.
 // Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
 type VideoData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	// video to send. Pass a file_id as String to send a video that exists on the Telegram servers (recommended),
 	// pass an HTTP URL as a String for Telegram to get a video from the Internet, or
 	// upload a new video using os.Open(<file_name>).
-	Video                    any             `json:"video"`
-	Duration                 int             `json:"duration"`
-	Width                    int             `json:"width"`
-	Height                   int             `json:"height"`
-	Caption                  string          `json:"caption"`
-	ParseMode                string          `json:"parse_mode"`
-	CaptionEntities          []MessageEntity `json:"caption_entities"`
-	SupportsStreaming        bool            `json:"supports_streaming"`
-	DisableNotification      bool            `json:"disable_notification"`
-	ReplyToMessageId         int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply"`
+	Video                    any             `json:"video" check:"required"`
+	Duration                 int             `json:"duration,omitempty"`
+	Width                    int             `json:"width,omitempty"`
+	Height                   int             `json:"height,omitempty"`
+	Caption                  string          `json:"caption,omitempty"`
+	ParseMode                string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
+	SupportsStreaming        bool            `json:"supports_streaming,omitempty"`
+	DisableNotification      bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -86,28 +93,30 @@ func (v VideoData) Send(b Bot) (Response, error) {
 }
 
 func (v VideoData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Video": v.Video, "ChatId": v.ChatId,
-		"ParseMode": v.ParseMode})
+	val := newValidator(v)
+	val.textLength("Caption", v.Caption, v.ParseMode, 0, MaxCaptionLength)
+	val.replyMarkup("ReplyMarkup", v.ReplyMarkup)
+	return val.err()
 }
 
 // AudioData sends audio files, if you want Telegram clients to display them in the music player.
 // Your audio must be in the .MP3 or .M4A format. On success, the sent Message is returned.
 // Bots can currently send audio files of up to 50 MB in size, this limit may be changed in the future.
 type AudioData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	// audio file to send. Pass a file_id as string to send an audio file that exists on the Telegram
 	// servers (recommended), pass an HTTP URL as a string for Telegram to get an audio file from the Internet,
 	// or upload a new video using os.Open(<file_name>).
-	Audio                    any             `json:"audio"`
-	Performer                string          `json:"performer"`
-	Title                    string          `json:"title"`
-	Duration                 int             `json:"duration"`
-	Caption                  string          `json:"caption"`
-	ParseMode                string          `json:"parse_mode"`
-	CaptionEntities          []MessageEntity `json:"caption_entities"`
-	DisableNotification      bool            `json:"disable_notification"`
-	ReplyToMessageId         int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply"`
+	Audio                    any             `json:"audio" check:"required"`
+	Performer                string          `json:"performer,omitempty"`
+	Title                    string          `json:"title,omitempty"`
+	Duration                 int             `json:"duration,omitempty"`
+	Caption                  string          `json:"caption,omitempty"`
+	ParseMode                string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
+	DisableNotification      bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -115,24 +124,27 @@ func (a AudioData) Send(b Bot) (Response, error) {
 	return Request("sendAudio", b, a, &ResponseImpl{Result: &Message{}})
 }
 func (a AudioData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Audio": a.Audio, "ChatId": a.ChatId, "ParseMode": a.ParseMode})
+	val := newValidator(a)
+	val.textLength("Caption", a.Caption, a.ParseMode, 0, MaxCaptionLength)
+	val.replyMarkup("ReplyMarkup", a.ReplyMarkup)
+	return val.err()
 }
 
 // DocumentData sends general files. On success, the sent Message is returned.
 // Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
 type DocumentData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	// file to send. Pass a file_id as string to send an audio file that exists on the Telegram
 	// servers (recommended), pass an HTTP URL as a string for Telegram to get a file from the Internet,
 	// or upload a new video using os.Open(<file_name>).
-	Document                    any             `json:"document"`
-	Caption                     string          `json:"caption"`
-	DisableContentTypeDetection bool            `json:"disable_content_type_detection"`
-	ParseMode                   string          `json:"parse_mode"`
-	CaptionEntities             []MessageEntity `json:"caption_entities"`
-	DisableNotification         bool            `json:"disable_notification"`
-	ReplyToMessageId            int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply    bool            `json:"allow_sending_without_reply"`
+	Document                    any             `json:"document" check:"required"`
+	Caption                     string          `json:"caption,omitempty"`
+	DisableContentTypeDetection bool            `json:"disable_content_type_detection,omitempty"`
+	ParseMode                   string          `json:"parse_mode,omitempty"`
+	CaptionEntities             []MessageEntity `json:"caption_entities,omitempty"`
+	DisableNotification         bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId            int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply    bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -140,8 +152,10 @@ func (d DocumentData) Send(b Bot) (Response, error) {
 	return Request("sendDocument", b, d, &ResponseImpl{Result: &Message{}})
 }
 func (d DocumentData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Document": d.Document, "ChatId": d.ChatId,
-		"ParseMode": d.ParseMode})
+	val := newValidator(d)
+	val.textLength("Caption", d.Caption, d.ParseMode, 0, MaxCaptionLength)
+	val.replyMarkup("ReplyMarkup", d.ReplyMarkup)
+	return val.err()
 }
 
 // VoiceData sends audio files, if you want Telegram clients to display the file as a playable voice message.
@@ -149,18 +163,18 @@ func (d DocumentData) Check() error {
 // (other formats may be sent as Audio or Document). On success, the sent Message is returned.
 // Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
 type VoiceData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	// audio file to send. Pass a file_id as string to send an audio file that exists on the Telegram
 	// servers (recommended), pass an HTTP URL as a string for Telegram to get an audio file from the Internet,
 	// or upload a new video using os.Open(<file_name>).
-	Voice                    any             `json:"voice"`
-	Duration                 int             `json:"duration"`
-	Caption                  string          `json:"caption"`
-	ParseMode                string          `json:"parse_mode"`
-	CaptionEntities          []MessageEntity `json:"caption_entities"`
-	DisableNotification      bool            `json:"disable_notification"`
-	ReplyToMessageId         int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply"`
+	Voice                    any             `json:"voice" check:"required"`
+	Duration                 int             `json:"duration,omitempty"`
+	Caption                  string          `json:"caption,omitempty"`
+	ParseMode                string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
+	DisableNotification      bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -168,25 +182,27 @@ func (v VoiceData) Send(b Bot) (Response, error) {
 	return Request("sendVoice", b, v, &ResponseImpl{Result: &Message{}})
 }
 func (v VoiceData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Voice": v.Voice, "ChatId": v.ChatId,
-		"ParseMode": v.ParseMode})
+	val := newValidator(v)
+	val.textLength("Caption", v.Caption, v.ParseMode, 0, MaxCaptionLength)
+	val.replyMarkup("ReplyMarkup", v.ReplyMarkup)
+	return val.err()
 }
 
 // AnimationData sends animation files (GIF or H.264/MPEG-4 AVC video without sound).
 // On success, the sent Message is returned.
 // Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
 type AnimationData struct {
-	ChatId                   int             `json:"chat_id"`
-	Animation                any             `json:"animation"`
-	Duration                 int             `json:"duration"`
-	Width                    int             `json:"width"`
-	Height                   int             `json:"height"`
-	Caption                  string          `json:"caption"`
-	ParseMode                string          `json:"parse_mode"`
-	CaptionEntities          []MessageEntity `json:"caption_entities"`
-	DisableNotification      bool            `json:"disable_notification"`
-	ReplyToMessageId         int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply"`
+	ChatId                   int             `json:"chat_id" check:"required"`
+	Animation                any             `json:"animation" check:"required"`
+	Duration                 int             `json:"duration,omitempty"`
+	Width                    int             `json:"width,omitempty"`
+	Height                   int             `json:"height,omitempty"`
+	Caption                  string          `json:"caption,omitempty"`
+	ParseMode                string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
+	DisableNotification      bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -194,45 +210,48 @@ func (a AnimationData) Send(b Bot) (Response, error) {
 	return Request("sendAnimation", b, a, &ResponseImpl{Result: &Message{}})
 }
 func (a AnimationData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Animation": a.Animation, "ChatId": a.ChatId,
-		"ParseMode": a.ParseMode})
+	val := newValidator(a)
+	val.textLength("Caption", a.Caption, a.ParseMode, 0, MaxCaptionLength)
+	val.replyMarkup("ReplyMarkup", a.ReplyMarkup)
+	return val.err()
 }
 
 // PollData sends a native poll. On success, the sent Message is returned.
 type PollData struct {
-	ChatId      int      `json:"chat_id"`
-	Question    string   `json:"question"`
-	Options     []string `json:"options"`
-	IsAnonymous bool     `json:"is_anonymous"`
+	ChatId   int      `json:"chat_id" check:"required"`
+	Question string   `json:"question" check:"required"`
+	Options  []string `json:"options" check:"required"`
+	// Optional. Polls are anonymous by default, so set it to a pointer to false for a public poll.
+	IsAnonymous *bool `json:"is_anonymous,omitempty"`
 	// Poll type, “quiz” or “regular”, defaults to “regular”
 	Type string `json:"type"`
 	// True, if the poll allows multiple answers,
 	// ignored for polls in quiz mode, defaults to False
-	AllowsMultipleAnswers bool `json:"allows_multiple_answers"`
+	AllowsMultipleAnswers bool `json:"allows_multiple_answers,omitempty"`
 	// 0-based identifier of the correct answer option,
-	// required for polls in quiz mode
-	CorrectOptionId int `json:"correct_option_id"`
+	// required for polls in quiz mode. Since 0 is a valid option, it is a pointer; leave it nil for regular polls.
+	CorrectOptionId *int `json:"correct_option_id,omitempty"`
 	// Text that is shown when a user chooses an
 	// incorrect answer or taps on the lamp icon in a
 	// quiz-style poll, 0-200 characters with at most 2 line
 	// feeds after entities parsing
-	Explanation          string          `json:"explanation"`
-	ExplanationParseMode string          `json:"explanation_parse_mode"`
-	ExplanationEntities  []MessageEntity `json:"explanation_entities"`
+	Explanation          string          `json:"explanation,omitempty"`
+	ExplanationParseMode string          `json:"explanation_parse_mode,omitempty"`
+	ExplanationEntities  []MessageEntity `json:"explanation_entities,omitempty"`
 	// Amount of time in seconds the poll will be active after
 	// creation, 5-600. Can't be used together with close_date.
-	OpenPeriod int `json:"open_period"`
+	OpenPeriod int `json:"open_period,omitempty"`
 	// Point in time (Unix timestamp) when the poll will
 	// be automatically closed. Must be at least 5 and no more
 	// than 600 seconds in the future.
 	// Can't be used together with open_period.
-	CloseDate int `json:"close_date"`
+	CloseDate int `json:"close_date,omitempty"`
 	// Pass True, if the poll needs to be immediately closed.
 	// This can be useful for poll preview.
-	IsClosed                 bool `json:"is_closed"`
-	DisableNotification      bool `json:"disable_notification"`
-	ReplyToMessageId         int  `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool `json:"allow_sending_without_reply"`
+	IsClosed                 bool `json:"is_closed,omitempty"`
+	DisableNotification      bool `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int  `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -240,23 +259,46 @@ func (p PollData) Send(b Bot) (Response, error) {
 	return Request("sendPoll", b, p, &ResponseImpl{Result: &Message{}})
 }
 func (p PollData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Question": p.Question, "ChatId": p.ChatId,
-		"Options": p.Options})
+	val := newValidator(p)
+	val.length("Question", p.Question, 1, 300)
+	if len(p.Options) < 2 || len(p.Options) > 10 {
+		val.add("Options", "must have 2-10 options, got "+strconv.Itoa(len(p.Options)))
+	}
+	for i, option := range p.Options {
+		val.length("Options["+strconv.Itoa(i)+"]", option, 1, 100)
+	}
+	if p.Type != "" {
+		val.oneOf("Type", p.Type, "regular", "quiz")
+	}
+	if p.CorrectOptionId != nil {
+		val.between("CorrectOptionId", *p.CorrectOptionId, 0, len(p.Options)-1)
+	} else if p.Type == "quiz" {
+		val.add("CorrectOptionId", "is required for polls in quiz mode")
+	}
+	val.textLength("Explanation", p.Explanation, p.ExplanationParseMode, 0, 200)
+	if p.OpenPeriod != 0 {
+		val.between("OpenPeriod", p.OpenPeriod, 5, 600)
+		if p.CloseDate != 0 {
+			val.add("CloseDate", "can't be used together with OpenPeriod")
+		}
+	}
+	val.replyMarkup("ReplyMarkup", p.ReplyMarkup)
+	return val.err()
 }
 
 // DiceData sends an animated emoji that will display a random value.
 // On success, the sent Message is returned.
 type DiceData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	// Emoji on which the dice throw animation is based.
 	// Currently, must be one of “🎲”, “🎯”, “🏀”, “⚽”, “🎳”, or “🎰”.
 	// Dice can have values 1-6 for “🎲”, “🎯” and “🎳”,
 	// values 1-5 for “🏀” and “⚽”, and values 1-64 for “🎰”.
 	// Defaults to “🎲”
-	Emoji                    string `json:"emoji"`
-	DisableNotification      bool   `json:"disable_notification"`
-	ReplyToMessageId         int    `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool   `json:"allow_sending_without_reply"`
+	Emoji                    string `json:"emoji,omitempty"`
+	DisableNotification      bool   `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int    `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool   `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -265,20 +307,26 @@ func (d DiceData) Send(b Bot) (Response, error) {
 }
 
 func (d DiceData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Emoji": d.Emoji, "ChatId": d.ChatId})
+	val := newValidator(d)
+	// an empty Emoji defaults to 🎲
+	if d.Emoji != "" {
+		val.oneOf("Emoji", d.Emoji, "🎲", "🎯", "🏀", "⚽", "🎳", "🎰")
+	}
+	val.replyMarkup("ReplyMarkup", d.ReplyMarkup)
+	return val.err()
 }
 
 // VideoNoteData sends video messages.
 // As of v.4.0, Telegram clients support rounded square mp4 videos of up to 1 minute long.
 // On success, the sent Message is returned.
 type VideoNoteData struct {
-	ChatId                   int  `json:"chat_id"`
-	VideoNote                any  `json:"videoNote"`
-	Duration                 int  `json:"duration"`
-	Length                   int  `json:"length"`
-	DisableNotification      bool `json:"disable_notification"`
-	ReplyToMessageId         int  `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool `json:"allow_sending_without_reply"`
+	ChatId                   int  `json:"chat_id" check:"required"`
+	VideoNote                any  `json:"video_note" check:"required"`
+	Duration                 int  `json:"duration,omitempty"`
+	Length                   int  `json:"length,omitempty"`
+	DisableNotification      bool `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int  `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -286,17 +334,19 @@ func (v VideoNoteData) Send(b Bot) (Response, error) {
 	return Request("sendVideoNote", b, v, &ResponseImpl{Result: &Message{}})
 }
 func (v VideoNoteData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"VideoNote": v.VideoNote, "ChatId": v.ChatId})
+	val := newValidator(v)
+	val.replyMarkup("ReplyMarkup", v.ReplyMarkup)
+	return val.err()
 }
 
 // LocationData sends point on the map.
 // On success, the sent Message is returned.
 type LocationData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	Location
-	DisableNotification      bool `json:"disable_notification"`
-	ReplyToMessageId         int  `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool `json:"allow_sending_without_reply"`
+	DisableNotification      bool `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int  `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -304,17 +354,76 @@ func (l LocationData) Send(b Bot) (Response, error) {
 	return Request("sendLocation", b, l, &ResponseImpl{Result: &Message{}})
 }
 func (l LocationData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": l.ChatId})
+	val := newValidator(l)
+	val.location(l.Location, l.LivePeriod != 0)
+	val.replyMarkup("ReplyMarkup", l.ReplyMarkup)
+	return val.err()
+}
+
+// EditMessageLiveLocationData edits a live location message, until its LivePeriod expires or it is stopped with
+// StopMessageLiveLocationData. On success, if the edited message is not an inline message, the edited Message
+// is returned, otherwise True is returned.
+type EditMessageLiveLocationData struct {
+	InlineMessageId      string  `json:"inline_message_id,omitempty"`
+	ChatId               int     `json:"chat_id,omitempty"`
+	MessageId            int     `json:"message_id,omitempty"`
+	Latitude             float64 `json:"latitude"`
+	Longitude            float64 `json:"longitude"`
+	HorizontalAccuracy   float64 `json:"horizontal_accuracy,omitempty"`
+	Heading              int     `json:"heading,omitempty"`
+	ProximityAlertRadius int     `json:"proximity_alert_radius,omitempty"`
+	InlineKeyboard       `json:"reply_markup,omitempty"`
+}
+
+func (e EditMessageLiveLocationData) Send(b Bot) (Response, error) {
+	return Request("editMessageLiveLocation", b, e, &ResponseImpl{})
+}
+func (e EditMessageLiveLocationData) Check() error {
+	if e.InlineMessageId == "" {
+		if e.ChatId == 0 || e.MessageId == 0 {
+			return errors.New("you need to set both MessageId and " +
+				"ChatId, otherwise set InlineMessageId")
+		}
+	}
+	val := newValidator(e)
+	val.location(Location{Latitude: e.Latitude, Longitude: e.Longitude, HorizontalAccuracy: e.HorizontalAccuracy,
+		Heading: e.Heading, ProximityAlertRadius: e.ProximityAlertRadius}, true)
+	val.inlineKeyboard("InlineKeyboard", e.InlineKeyboard)
+	return val.err()
+}
+
+// StopMessageLiveLocationData stops updating a live location message before its LivePeriod expires.
+// On success, if the message is not an inline message, the edited Message is returned, otherwise True is returned.
+type StopMessageLiveLocationData struct {
+	InlineMessageId string `json:"inline_message_id,omitempty"`
+	ChatId          int    `json:"chat_id,omitempty"`
+	MessageId       int    `json:"message_id,omitempty"`
+	InlineKeyboard  `json:"reply_markup,omitempty"`
+}
+
+func (s StopMessageLiveLocationData) Send(b Bot) (Response, error) {
+	return Request("stopMessageLiveLocation", b, s, &ResponseImpl{})
+}
+func (s StopMessageLiveLocationData) Check() error {
+	if s.InlineMessageId == "" {
+		if s.ChatId == 0 || s.MessageId == 0 {
+			return errors.New("you need to set both MessageId and " +
+				"ChatId, otherwise set InlineMessageId")
+		}
+	}
+	val := newValidator(s)
+	val.inlineKeyboard("InlineKeyboard", s.InlineKeyboard)
+	return val.err()
 }
 
 // ContactData sends phone contacts.
 // On success, the sent Message is returned.
 type ContactData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	Contact
-	DisableNotification      bool `json:"disable_notification"`
-	ReplyToMessageId         int  `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool `json:"allow_sending_without_reply"`
+	DisableNotification      bool `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int  `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -322,25 +431,67 @@ func (c ContactData) Send(b Bot) (Response, error) {
 	return Request("sendContact", b, c, &ResponseImpl{Result: &Message{}})
 }
 func (c ContactData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"PhoneNumber": c.PhoneNumber, "ChatId": c.ChatId,
-		"FirstName": c.FirstName})
+	val := newValidator(c)
+	if c.PhoneNumber == "" {
+		val.add("PhoneNumber", "is empty")
+	}
+	if c.FirstName == "" {
+		val.add("FirstName", "is empty")
+	}
+	val.replyMarkup("ReplyMarkup", c.ReplyMarkup)
+	return val.err()
+}
+
+// VenueData sends information about a venue.
+// On success, the sent Message is returned.
+type VenueData struct {
+	ChatId    int     `json:"chat_id" check:"required"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Title     string  `json:"title" check:"required"`
+	Address   string  `json:"address" check:"required"`
+	// Foursquare identifier and type of the venue. Types are like "arts_entertainment/default" or
+	// "food/icecream".
+	FoursquareId   string `json:"foursquare_id,omitempty"`
+	FoursquareType string `json:"foursquare_type,omitempty"`
+	// Google Places identifier and type of the venue. See https://developers.google.com/places/web-service/supported_types
+	GooglePlaceId            string `json:"google_place_id,omitempty"`
+	GooglePlaceType          string `json:"google_place_type,omitempty"`
+	DisableNotification      bool   `json:"disable_notification,omitempty"`
+	ProtectContent           bool   `json:"protect_content,omitempty"`
+	ReplyToMessageId         int    `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool   `json:"allow_sending_without_reply,omitempty"`
+	Keyboard
+}
+
+func (v VenueData) Send(b Bot) (Response, error) {
+	return Request("sendVenue", b, v, &ResponseImpl{Result: &Message{}})
+}
+func (v VenueData) Check() error {
+	val := newValidator(v)
+	val.location(Location{Latitude: v.Latitude, Longitude: v.Longitude}, false)
+	val.replyMarkup("ReplyMarkup", v.ReplyMarkup)
+	return val.err()
 }
 
 // MediaGroupData sends a group of photos, videos, documents or audios as an album.
 // Documents and audio files can be only grouped in an album with messages of the same type.
 // On success, an array of Messages that were sent is returned.
 type MediaGroupData struct {
-	ChatId int          `json:"chat_id"`
-	Media  []InputMedia `json:"media"`
+	ChatId int          `json:"chat_id" check:"required"`
+	Media  []InputMedia `json:"media,omitempty"`
 	// leave this field. it will be set automatically.
 	Files                    []*os.File
-	ReplyToMessageId         int  `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool `json:"allow_sending_without_reply"`
+	ReplyToMessageId         int  `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
 }
 
 func (m MediaGroupData) Send(b Bot) (Response, error) {
 	for _, j := range m.Media {
-		m.Files = append(m.Files, j.returnFile())
+		// media sent by file_id or url have no file to upload
+		if file := j.returnFile(); file != nil {
+			m.Files = append(m.Files, file)
+		}
 	}
 	return Request("sendMediaGroup", b, m, &ResponseImpl{Result: &[]Message{}})
 }
@@ -349,29 +500,56 @@ func (m MediaGroupData) Check() error {
 		return errors.New("media slice is empty. pass media a slice of structs of type " +
 			"InputMediaPhoto, InputMediaVideo, InputMediaDocument or InputMediaAudio")
 	}
-	return nil
+	val := newValidator(m)
+	if len(m.Media) < 2 || len(m.Media) > 10 {
+		val.add("Media", "must have 2-10 items, got "+strconv.Itoa(len(m.Media)))
+	}
+	// documents and audios can only be grouped with media of the same type; photos and videos can be mixed
+	kinds := map[string]bool{}
+	for i, j := range m.Media {
+		field := "Media[" + strconv.Itoa(i) + "]"
+		switch media := j.(type) {
+		case *InputMediaPhoto:
+			kinds["photo or video"] = true
+			val.textLength(field+".Caption", media.Caption, media.ParseMode, 0, MaxCaptionLength)
+		case *InputMediaVideo:
+			kinds["photo or video"] = true
+			val.textLength(field+".Caption", media.Caption, media.ParseMode, 0, MaxCaptionLength)
+		case *InputMediaDocument:
+			kinds["document"] = true
+			val.textLength(field+".Caption", media.Caption, media.ParseMode, 0, MaxCaptionLength)
+		case *InputMediaAudio:
+			kinds["audio"] = true
+			val.textLength(field+".Caption", media.Caption, media.ParseMode, 0, MaxCaptionLength)
+		default:
+			val.add(field, "must be InputMediaPhoto, InputMediaVideo, InputMediaDocument or InputMediaAudio")
+		}
+	}
+	if len(kinds) > 1 {
+		val.add("Media", "can't mix documents or audios with other types of media")
+	}
+	return val.err()
 }
 
 // ForwardMessageData forwards messages of any kind. Service messages can't be forwarded.
 // On success, the sent Message is returned.
 type ForwardMessageData struct {
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	// Unique identifier for the chat where the original message was
 	// sent (or channel username in the format @channelusername)
-	FromChatId int `json:"from_chat_id"`
+	FromChatId int `json:"from_chat_id" check:"required"`
 	// message identifier in the chat specified in from_chat_id
-	MessageId           int  `json:"message_id"`
-	DisableNotification bool `json:"disable_notification"`
-	ProtectContent      bool `json:"protect_content"`
+	MessageId           int  `json:"message_id" check:"required"`
+	DisableNotification bool `json:"disable_notification,omitempty"`
+	ProtectContent      bool `json:"protect_content,omitempty"`
 }
 
 func (f ForwardMessageData) Send(b Bot) (Response, error) {
 	return Request("forwardMessage", b, f, &ResponseImpl{Result: &Message{}})
 }
 func (f ForwardMessageData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"FromChatId": f.FromChatId, "ChatId": f.ChatId,
-		"MessageId": f.MessageId})
+	return newValidator(f).err()
 }
 
 // CopyMessageData copies messages of any kind. Service messages and invoice messages can't be copied.
@@ -379,18 +557,18 @@ func (f ForwardMessageData) Check() error {
 // the original message. Returns the MessageId of the sent message on success.
 type CopyMessageData struct {
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 	// Unique identifier for the chat where the original message was sent
 	// (or channel username in the format @channelusername)
-	FromChatId int `json:"from_chat_id"`
+	FromChatId int `json:"from_chat_id" check:"required"`
 	// Message identifier in the chat specified in from_chat_id
-	MessageId                int             `json:"message_id"`
-	Caption                  string          `json:"caption"`
-	ParseMode                string          `json:"parse_mode"`
-	CaptionEntities          []MessageEntity `json:"caption_entities"`
-	DisableNotification      bool            `json:"disable_notification"`
-	ReplyToMessageId         int             `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply"`
+	MessageId                int             `json:"message_id" check:"required"`
+	Caption                  string          `json:"caption,omitempty"`
+	ParseMode                string          `json:"parse_mode,omitempty"`
+	CaptionEntities          []MessageEntity `json:"caption_entities,omitempty"`
+	DisableNotification      bool            `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int             `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool            `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -398,8 +576,10 @@ func (c CopyMessageData) Send(b Bot) (Response, error) {
 	return Request("copyMessage", b, c, &ResponseImpl{Result: &Message{}})
 }
 func (c CopyMessageData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"FromChatId": c.FromChatId, "ChatId": c.ChatId,
-		"MessageId": c.MessageId, "ParseMode": c.ParseMode})
+	val := newValidator(c)
+	val.textLength("Caption", c.Caption, c.ParseMode, 0, MaxCaptionLength)
+	val.replyMarkup("ReplyMarkup", c.ReplyMarkup)
+	return val.err()
 }
 
 // DeleteMessageData deletes a message, including service messages, with the following limitations:
@@ -412,15 +592,15 @@ func (c CopyMessageData) Check() error {
 //- If the bot has can_delete_messages permission in a supergroup or a channel, it can delete any message there.
 // Returns true on success.
 type DeleteMessageData struct {
-	ChatId    int `json:"chat_id"`
-	MessageId int `json:"message_id"`
+	ChatId    int `json:"chat_id" check:"required"`
+	MessageId int `json:"message_id" check:"required"`
 }
 
 func (d DeleteMessageData) Send(b Bot) (Response, error) {
 	return Request("deleteMessage", b, d, &ResponseImpl{})
 }
 func (d DeleteMessageData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": d.ChatId, "MessageId": d.MessageId})
+	return newValidator(d).err()
 }
 
 // DeleteChatStickerSetData deletes a group sticker set from a supergroup.
@@ -429,14 +609,14 @@ func (d DeleteMessageData) Check() error {
 // requests to check if the bot can use this method.
 // Returns True on success.
 type DeleteChatStickerSetData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (d DeleteChatStickerSetData) Send(b Bot) (Response, error) {
 	return Request("deleteChatStickerSet", b, d, &ResponseImpl{})
 }
 func (d DeleteChatStickerSetData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": d.ChatId})
+	return newValidator(d).err()
 }
 
 // SetChatStickerSetData sets a new group sticker set for a supergroup.
@@ -445,83 +625,83 @@ func (d DeleteChatStickerSetData) Check() error {
 // requests to check if the bot can use this method.
 // Returns True on success.
 type SetChatStickerSetData struct {
-	ChatId         int    `json:"chat_id"`
-	StickerSetName string `json:"sticker_set_name"`
+	ChatId         int    `json:"chat_id" check:"required"`
+	StickerSetName string `json:"sticker_set_name" check:"required"`
 }
 
 func (s SetChatStickerSetData) Send(b Bot) (Response, error) {
 	return Request("setChatStickerSet", b, s, &ResponseImpl{})
 }
 func (s SetChatStickerSetData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "StickerSetName": s.StickerSetName})
+	return newValidator(s).err()
 }
 
 // GetChatMemberData gets information about a member of a chat.
-// Returns a ChatMember (https://core.telegram.org/bots/api#chatmember) object on success.
+// Returns a *Member on success.
 type GetChatMemberData struct {
-	ChatId int `json:"chat_id"`
-	UserId int `json:"user_id"`
+	ChatId int `json:"chat_id" check:"required"`
+	UserId int `json:"user_id" check:"required"`
 }
 
 func (g GetChatMemberData) Send(b Bot) (Response, error) {
-	return Request("getChatMember", b, g, &ResponseImpl{})
+	return Request("getChatMember", b, g, &ResponseImpl{Result: &Member{}})
 }
 
 func (g GetChatMemberData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": g.ChatId, "UserId": g.UserId})
+	return newValidator(g).err()
 }
 
 // GetChatMemberCountData gets the number of members in a chat. Returns Int on success.
 type GetChatMemberCountData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (g GetChatMemberCountData) Send(b Bot) (Response, error) {
 	return Request("getChatMemberCount", b, g, &ResponseImpl{})
 }
 func (g GetChatMemberCountData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": g.ChatId})
+	return newValidator(g).err()
 }
 
 // GetChatAdministratorsData gets a list of administrators in a chat.
-// On success, returns an array of ChatMember (https://core.telegram.org/bots/api#chatmember) objects
+// On success, returns a *[]Member
 // that contains information about all chat administrators except other bots.
 // If the chat is a group or a supergroup and no administrators were appointed, only the creator will be returned.
 type GetChatAdministratorsData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (g GetChatAdministratorsData) Send(b Bot) (Response, error) {
-	return Request("getChatAdministrators", b, g, &ResponseImpl{})
+	return Request("getChatAdministrators", b, g, &ResponseImpl{Result: &[]Member{}})
 }
 func (g GetChatAdministratorsData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": g.ChatId})
+	return newValidator(g).err()
 }
 
 // GetChatData gets up-to-date information about the chat (current name of the user for one-on-one
 // conversations, current username of a user, group or channel, etc.). Returns a Chat object on success.
 type GetChatData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (g GetChatData) Send(b Bot) (Response, error) {
 	return Request("getChat", b, g, &ResponseImpl{Result: &Chat{}})
 }
 func (g GetChatData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": g.ChatId})
+	return newValidator(g).err()
 }
 
 // LeaveChatData leaves a group, supergroup or channel for your bot.
 // Returns True on success.
 type LeaveChatData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (l LeaveChatData) Send(b Bot) (Response, error) {
 	return Request("leaveChat", b, l, &ResponseImpl{})
 }
 func (l LeaveChatData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": l.ChatId})
+	return newValidator(l).err()
 }
 
 // UnpinAllChatMessagesData clears the list of pinned messages in a chat.
@@ -530,73 +710,73 @@ func (l LeaveChatData) Check() error {
 // 'can_edit_messages' administrator right in a channel.
 // Returns True on success.
 type UnpinAllChatMessagesData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (u UnpinAllChatMessagesData) Send(b Bot) (Response, error) {
 	return Request("unpinAllChatMessages", b, u, &ResponseImpl{})
 }
 func (u UnpinAllChatMessagesData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": u.ChatId})
+	return newValidator(u).err()
 }
 
 // SetChatDescriptionData changes the description of a group, a supergroup or a channel.
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type SetChatDescriptionData struct {
-	ChatId      int    `json:"chat_id"`
-	Description string `json:"description"`
+	ChatId      int    `json:"chat_id" check:"required"`
+	Description string `json:"description" check:"required"`
 }
 
 func (s SetChatDescriptionData) Send(b Bot) (Response, error) {
 	return Request("setChatDescription", b, s, &ResponseImpl{})
 }
 func (s SetChatDescriptionData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "Description": s.Description})
+	return newValidator(s).err()
 }
 
 // SetChatTitleData changes the title of a chat. Titles can't be changed for private chats.
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type SetChatTitleData struct {
-	ChatId int    `json:"chat_id"`
-	Title  string `json:"title"`
+	ChatId int    `json:"chat_id" check:"required"`
+	Title  string `json:"title" check:"required"`
 }
 
 func (s SetChatTitleData) Send(b Bot) (Response, error) {
 	return Request("setChatTitle", b, s, &ResponseImpl{})
 }
 func (s SetChatTitleData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "Title": s.Title})
+	return newValidator(s).err()
 }
 
 // DeleteChatPhotoData deletes a chat photo. Photos can't be changed for private chats.
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type DeleteChatPhotoData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (d DeleteChatPhotoData) Send(b Bot) (Response, error) {
 	return Request("deleteChatPhoto", b, d, &ResponseImpl{})
 }
 func (d DeleteChatPhotoData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": d.ChatId})
+	return newValidator(d).err()
 }
 
 // SetChatPhotoData sets a new profile photo for the chat. Photos can't be changed for private chats.
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type SetChatPhotoData struct {
-	ChatId int      `json:"chat_id"`
-	Photo  *os.File `json:"photo"`
+	ChatId int      `json:"chat_id" check:"required"`
+	Photo  *os.File `json:"photo" check:"required"`
 }
 
 func (s SetChatPhotoData) Send(b Bot) (Response, error) {
 	return Request("setChatPhoto", b, s, &ResponseImpl{})
 }
 func (s SetChatPhotoData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "Photo": s.Photo})
+	return newValidator(s).err()
 }
 
 // RevokeChatInviteLinkData revokes an invitation link created by the bot.
@@ -604,15 +784,15 @@ func (s SetChatPhotoData) Check() error {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns the revoked invite link as ChatInviteLink object.
 type RevokeChatInviteLinkData struct {
-	ChatId     int    `json:"chat_id"`
-	InviteLink string `json:"invite_link"`
+	ChatId     int    `json:"chat_id" check:"required"`
+	InviteLink string `json:"invite_link" check:"required"`
 }
 
 func (r RevokeChatInviteLinkData) Send(b Bot) (Response, error) {
 	return Request("revokeChatInviteLink", b, r, &ResponseImpl{Result: &ChatInviteLink{}})
 }
 func (r RevokeChatInviteLinkData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": r.ChatId, "InviteLink": r.InviteLink})
+	return newValidator(r).err()
 }
 
 // ExportChatInviteLinkData generates a new primary invite link for a chat; any previously generated primary
@@ -620,14 +800,14 @@ func (r RevokeChatInviteLinkData) Check() error {
 // appropriate administrator rights.
 // Returns the new invite link as String on success.
 type ExportChatInviteLinkData struct {
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id" check:"required"`
 }
 
 func (e ExportChatInviteLinkData) Send(b Bot) (Response, error) {
 	return Request("exportChatInviteLink", b, e, &ResponseImpl{})
 }
 func (e ExportChatInviteLinkData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": e.ChatId})
+	return newValidator(e).err()
 }
 
 // SendChatActionData tells the user that something is happening on the bot's side.
@@ -635,8 +815,8 @@ func (e ExportChatInviteLinkData) Check() error {
 // clients clear its typing status). for more info visit https://core.telegram.org/bots/api#sendchataction
 // Returns True on success.
 type SendChatActionData struct {
-	ChatId int    `json:"chat_id"`
-	Action string `json:"action"`
+	ChatId int    `json:"chat_id" check:"required"`
+	Action string `json:"action,omitempty"`
 }
 
 func (s SendChatActionData) Send(b Bot) (Response, error) {
@@ -649,7 +829,7 @@ func (s SendChatActionData) Check() error {
 	if _, ok := actions[s.Action]; ok == false {
 		return errors.New(s.Action + " is an unknown action, read the document")
 	}
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId})
+	return newValidator(s).err()
 }
 
 // GetFileData gets basic info about a file and prepare it for downloading.
@@ -660,14 +840,14 @@ func (s SendChatActionData) Check() error {
 // It is guaranteed that the link will be valid for at least 1 hour.
 // When the link expires, a new one can be requested by calling getFile again.
 type GetFileData struct {
-	FileId string `json:"file_id"`
+	FileId string `json:"file_id" check:"required"`
 }
 
 func (g GetFileData) Send(b Bot) (Response, error) {
 	return Request("getFile", b, g, &ResponseImpl{Result: &File{}})
 }
 func (g GetFileData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"FileId": g.FileId})
+	return newValidator(g).err()
 }
 
 // UnbanChatMemberData unbans a previously banned user in a supergroup or channel.
@@ -677,24 +857,24 @@ func (g GetFileData) Check() error {
 // the chat they will also be removed from the chat. If you don't want this, use the parameter only_if_banned.
 // Returns True on success.
 type UnbanChatMemberData struct {
-	ChatId       int  `json:"chat_id"`
-	UserId       int  `json:"user_id"`
-	OnlyIfBanned bool `json:"only_if_banned"`
+	ChatId       int  `json:"chat_id" check:"required"`
+	UserId       int  `json:"user_id" check:"required"`
+	OnlyIfBanned bool `json:"only_if_banned,omitempty"`
 }
 
 func (u UnbanChatMemberData) Send(b Bot) (Response, error) {
 	return Request("unbanChatMember", b, u, &ResponseImpl{})
 }
 func (u UnbanChatMemberData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": u.ChatId, "UserId": u.UserId})
+	return newValidator(u).err()
 }
 
 // SetChatAdministratorCustomTitleData sets a custom title for an administrator in a supergroup promoted by the bot.
 // Returns True on success.
 type SetChatAdministratorCustomTitleData struct {
-	ChatId      int    `json:"chat_id"`
-	UserId      int    `json:"user_id"`
-	CustomTitle string `json:"custom_title"`
+	ChatId      int    `json:"chat_id" check:"required"`
+	UserId      int    `json:"user_id" check:"required"`
+	CustomTitle string `json:"custom_title" check:"required"`
 }
 
 func (s SetChatAdministratorCustomTitleData) Send(b Bot) (Response, error) {
@@ -702,15 +882,14 @@ func (s SetChatAdministratorCustomTitleData) Send(b Bot) (Response, error) {
 }
 
 func (s SetChatAdministratorCustomTitleData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "UserId": s.UserId,
-		"CustomTitle": s.CustomTitle})
+	return newValidator(s).err()
 }
 
 // SetChatPermissionsData sets default chat permissions for all members.
 // The bot must be an administrator in the group or a supergroup for this to work and must
 // have the can_restrict_members administrator rights. Returns True on success.
 type SetChatPermissionsData struct {
-	ChatId      int             `json:"chat_id"`
+	ChatId      int             `json:"chat_id" check:"required"`
 	Permissions ChatPermissions `json:"permissions"`
 }
 
@@ -718,25 +897,25 @@ func (s SetChatPermissionsData) Send(b Bot) (Response, error) {
 	return Request("setChatPermissions", b, s, &ResponseImpl{})
 }
 func (s SetChatPermissionsData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId})
+	return newValidator(s).err()
 }
 
 // GetUserProfilePhotosData gets a list of profile pictures for a user. Returns a UserProfilePhotos object.
 type GetUserProfilePhotosData struct {
-	UserId int `json:"user_id"`
+	UserId int `json:"user_id" check:"required"`
 	// Sequential number of the first photo to be returned.
 	// By default, all photos are returned.
-	Offset int `json:"offset"`
+	Offset int `json:"offset,omitempty"`
 	// Limits the number of photos to be retrieved.
 	// Values between 1-100 are accepted. Defaults to 100.
-	Limit int `json:"limit"`
+	Limit int `json:"limit,omitempty"`
 }
 
 func (u GetUserProfilePhotosData) Send(b Bot) (Response, error) {
 	return Request("getUserProfilePhotos", b, u, &ResponseImpl{Result: &UserProfilePhotos{}})
 }
 func (u GetUserProfilePhotosData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"UserId": u.UserId})
+	return newValidator(u).err()
 }
 
 // BanChatMemberData bans a user in a group, a supergroup or a channel.
@@ -745,115 +924,153 @@ func (u GetUserProfilePhotosData) Check() error {
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns True on success.
 type BanChatMemberData struct {
-	ChatId int `json:"chat_id"`
-	UserId int `json:"user_id"`
+	ChatId int `json:"chat_id" check:"required"`
+	UserId int `json:"user_id" check:"required"`
 	// Date when the user will be unbanned, unix time.
 	// If user is banned for more than 366 days or less
 	// than 30 seconds from the current time they are considered to be banned forever.
 	// Applied for supergroups and channels only.
-	UntilDate int `json:"until_date"`
+	UntilDate int `json:"until_date,omitempty"`
 	// Pass True to delete all messages from the chat for the user that is being removed.
 	// If False, the user will be able to see messages in the group that were sent before
 	// the user was removed. Always True for supergroups and channels.
-	RevokeMessages bool `json:"revoke_messages"`
+	RevokeMessages bool `json:"revoke_messages,omitempty"`
 }
 
 func (ban BanChatMemberData) Send(b Bot) (Response, error) {
 	return Request("banChatMember", b, ban, &ResponseImpl{})
 }
 func (ban BanChatMemberData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"UserId": ban.UserId, "ChatId": ban.ChatId})
+	return newValidator(ban).err()
 }
 
 // RestrictChatMemberData restricts a user in a supergroup. The bot must be an administrator in the
 // supergroup for this to work and must have the appropriate administrator rights.
 // Pass True for all permissions to lift restrictions from a user. Returns True on success.
 type RestrictChatMemberData struct {
-	ChatId      int             `json:"chat_id"`
-	UserId      int             `json:"user_id"`
+	ChatId      int             `json:"chat_id" check:"required"`
+	UserId      int             `json:"user_id" check:"required"`
 	Permissions ChatPermissions `json:"permissions"`
-	UntilDate   int             `json:"until_date"`
+	UntilDate   int             `json:"until_date,omitempty"`
 }
 
 func (r RestrictChatMemberData) Send(b Bot) (Response, error) {
 	return Request("restrictChatMember", b, r, &ResponseImpl{})
 }
 func (r RestrictChatMemberData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"UserId": r.UserId, "ChatId": r.ChatId})
+	return newValidator(r).err()
 }
 
 // PromoteChatMemberData promotes or demotes a user in a supergroup or a channel.
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Pass False for all boolean parameters to demote a user. Returns True on success.
 type PromoteChatMemberData struct {
-	ChatId int `json:"chat_id"`
-	UserId int `json:"user_id"`
+	ChatId int `json:"chat_id" check:"required"`
+	UserId int `json:"user_id" check:"required"`
 	// Pass True, if the administrator's presence in the chat is hidden.
-	IsAnonymous bool `json:"is_anonymous"`
+	IsAnonymous bool `json:"is_anonymous,omitempty"`
 	// Pass True, if the administrator can access the chat event log, chat statistics,
 	// message statistics in channels, see channel members, see anonymous administrators
 	// in supergroups and ignore slow mode. Implied by any other administrator privilege.
-	CanManageChat bool `json:"can_manage_chat"`
+	CanManageChat bool `json:"can_manage_chat,omitempty"`
 	// Pass True, if the administrator can create channel posts, channels only.
-	CanPostMessages bool `json:"can_post_messages"`
+	CanPostMessages bool `json:"can_post_messages,omitempty"`
 	// Pass True, if the administrator can edit messages of other users and can pin messages, channels only.
-	CanEditMessages bool `json:"can_edit_messages"`
+	CanEditMessages bool `json:"can_edit_messages,omitempty"`
 	// Pass True, if the administrator can delete messages of other users.
-	CanDeleteMessages bool `json:"can_delete_messages"`
+	CanDeleteMessages bool `json:"can_delete_messages,omitempty"`
 	// Pass True, if the administrator can manage voice chats.
-	CanManageVoiceChats bool `json:"can_manage_voice_chats"`
+	CanManageVoiceChats bool `json:"can_manage_voice_chats,omitempty"`
 	// Pass True, if the administrator can restrict, ban or unban chat members.
-	CanRestrictMembers bool `json:"can_restrict_members"`
+	CanRestrictMembers bool `json:"can_restrict_members,omitempty"`
 	// Pass True, if the administrator can add new administrators with a subset of their own privileges
 	// or demote administrators that he has promoted, directly or indirectly
 	// (promoted by administrators that were appointed by him)
-	CanPromoteMembers bool `json:"can_promote_members"`
+	CanPromoteMembers bool `json:"can_promote_members,omitempty"`
 	// Pass True, if the administrator can change chat title, photo and other settings
-	CanChangeInfo bool `json:"can_change_info"`
+	CanChangeInfo bool `json:"can_change_info,omitempty"`
 	// Pass True, if the administrator can invite new users to the chat
-	CanInviteUsers bool `json:"can_invite_users"`
+	CanInviteUsers bool `json:"can_invite_users,omitempty"`
 	// Pass True, if the administrator can pin messages, supergroups only
-	CanPinMessages bool `json:"can_pin_messages"`
+	CanPinMessages bool `json:"can_pin_messages,omitempty"`
 }
 
 func (p PromoteChatMemberData) Send(b Bot) (Response, error) {
 	return Request("promoteChatMember", b, p, &ResponseImpl{})
 }
 func (p PromoteChatMemberData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"UserId": p.UserId, "ChatId": p.ChatId})
+	return newValidator(p).err()
 }
 
 // CreateChatInviteLinkData creates an additional invite link for a chat.
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // The link can be revoked using the method revokeChatInviteLink. Returns the new invite link as ChatInviteLink object.
 type CreateChatInviteLinkData struct {
-	ChatId      int `json:"chat_id"`
-	ExpireDate  int `json:"expire_date"`
-	MemberLimit int `json:"member_limit"`
+	ChatId      int `json:"chat_id" check:"required"`
+	ExpireDate  int `json:"expire_date,omitempty"`
+	MemberLimit int `json:"member_limit,omitempty"`
+	// CreatesJoinRequest makes users joining via the link send a ChatJoinRequest that must be approved.
+	// MemberLimit can't be set if it is true.
+	CreatesJoinRequest bool `json:"creates_join_request,omitempty"`
 }
 
 func (c CreateChatInviteLinkData) Send(b Bot) (Response, error) {
 	return Request("createChatInviteLink", b, c, &ResponseImpl{Result: &ChatInviteLink{}})
 }
 func (c CreateChatInviteLinkData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": c.ChatId})
+	val := newValidator(c)
+	val.inviteLink(c.MemberLimit, c.CreatesJoinRequest)
+	return val.err()
 }
 
 // EditChatInviteLinkData edits a non-primary invite link created by the bot.
 // The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights.
 // Returns the edited invite link as a ChatInviteLink object.
 type EditChatInviteLinkData struct {
-	ChatId      int    `json:"chat_id"`
-	InviteLink  string `json:"invite_link"`
-	ExpireDate  int    `json:"expire_date"`
-	MemberLimit int    `json:"member_limit"`
+	ChatId      int    `json:"chat_id" check:"required"`
+	InviteLink  string `json:"invite_link" check:"required"`
+	ExpireDate  int    `json:"expire_date,omitempty"`
+	MemberLimit int    `json:"member_limit,omitempty"`
+	// CreatesJoinRequest makes users joining via the link send a ChatJoinRequest that must be approved.
+	// MemberLimit can't be set if it is true.
+	CreatesJoinRequest bool `json:"creates_join_request,omitempty"`
 }
 
 func (e EditChatInviteLinkData) Send(b Bot) (Response, error) {
 	return Request("editChatInviteLink", b, e, &ResponseImpl{Result: &ChatInviteLink{}})
 }
 func (e EditChatInviteLinkData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": e.ChatId, "InviteLink": e.InviteLink})
+	val := newValidator(e)
+	val.inviteLink(e.MemberLimit, e.CreatesJoinRequest)
+	return val.err()
+}
+
+// ApproveChatJoinRequestData approves a chat join request. The bot must be an administrator in the chat
+// for this to work and must have the can_invite_users administrator right. Returns True on success.
+type ApproveChatJoinRequestData struct {
+	ChatId int `json:"chat_id" check:"required"`
+	UserId int `json:"user_id" check:"required"`
+}
+
+func (a ApproveChatJoinRequestData) Send(b Bot) (Response, error) {
+	return Request("approveChatJoinRequest", b, a, &ResponseImpl{})
+}
+func (a ApproveChatJoinRequestData) Check() error {
+	return newValidator(a).err()
+}
+
+// DeclineChatJoinRequestData declines a chat join request. The bot must be an administrator in the chat
+// for this to work and must have the can_invite_users administrator right. Returns True on success.
+type DeclineChatJoinRequestData struct {
+	ChatId int `json:"chat_id" check:"required"`
+	UserId int `json:"user_id" check:"required"`
+}
+
+func (d DeclineChatJoinRequestData) Send(b Bot) (Response, error) {
+	return Request("declineChatJoinRequest", b, d, &ResponseImpl{})
+}
+func (d DeclineChatJoinRequestData) Check() error {
+	return newValidator(d).err()
 }
 
 // PinChatMessageData adds a message to the list of pinned messages in a chat.
@@ -861,16 +1078,16 @@ func (e EditChatInviteLinkData) Check() error {
 // this to work and must have the 'can_pin_messages' administrator right in a supergroup or
 // 'can_edit_messages' administrator right in a channel. Returns True on success.
 type PinChatMessageData struct {
-	ChatId              int  `json:"chat_id"`
-	MessageId           int  `json:"message_id"`
-	DisableNotification bool `json:"disable_notification"`
+	ChatId              int  `json:"chat_id" check:"required"`
+	MessageId           int  `json:"message_id" check:"required"`
+	DisableNotification bool `json:"disable_notification,omitempty"`
 }
 
 func (p PinChatMessageData) Send(b Bot) (Response, error) {
 	return Request("pinChatMessage", b, p, &ResponseImpl{})
 }
 func (p PinChatMessageData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": p.ChatId, "MessageId": p.MessageId})
+	return newValidator(p).err()
 }
 
 // UnpinChatMessageData removes a message from the list of pinned messages in a chat.
@@ -878,43 +1095,49 @@ func (p PinChatMessageData) Check() error {
 // to work and must have the 'can_pin_messages' administrator right in a supergroup or
 // 'can_edit_messages' administrator right in a channel. Returns True on success.
 type UnpinChatMessageData struct {
-	ChatId    int `json:"chat_id"`
-	MessageId int `json:"message_id"`
+	ChatId    int `json:"chat_id" check:"required"`
+	MessageId int `json:"message_id" check:"required"`
 }
 
 func (u UnpinChatMessageData) Send(b Bot) (Response, error) {
 	return Request("unpinChatMessage", b, u, &ResponseImpl{})
 }
 func (u UnpinChatMessageData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": u.ChatId, "MessageId": u.MessageId})
+	return newValidator(u).err()
 }
 
 // AnswerCallbackQueryData sends answers to callback queries sent from inline keyboards.
 // The answer will be displayed to the user as a notification at the top of the chat screen or as an alert.
 // On success, True is returned. more info in https://core.telegram.org/bots/api#answercallbackquery
 type AnswerCallbackQueryData struct {
-	CallbackQueryId string `json:"callback_query_id"`
-	Text            string `json:"text"`
-	ShowAlert       bool   `json:"show_alert"`
-	Url             string `json:"url"`
-	CacheTime       string `json:"cache_time"`
+	CallbackQueryId string `json:"callback_query_id" check:"required"`
+	Text            string `json:"text,omitempty"`
+	ShowAlert       bool   `json:"show_alert,omitempty"`
+	Url             string `json:"url,omitempty"`
+	CacheTime       string `json:"cache_time,omitempty"`
 }
 
 func (a AnswerCallbackQueryData) Send(b Bot) (Response, error) {
-	return Request("answerCallbackQuery", b, a, &ResponseImpl{})
+	res, err := Request("answerCallbackQuery", b, a, &ResponseImpl{})
+	if err == nil {
+		markAnswered(a.CallbackQueryId)
+	}
+	return res, err
 }
 func (a AnswerCallbackQueryData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"CallbackQueryId": a.CallbackQueryId})
+	val := newValidator(a)
+	val.length("Text", a.Text, 0, 200)
+	return val.err()
 }
 
 // SetMyCommandsData changes the list of the bot's commands.
 // See https://core.telegram.org/bots#commands for more details about bot commands.
 // Returns True on success.
 type SetMyCommandsData struct {
-	Commands []BotCommand `json:"commands"`
+	Commands []BotCommand `json:"commands" check:"required"`
 	// Scope describing scope of users for which the commands are relevant. Defaults to "default".
-	Scope        BotCommandScope `json:"scope"`
-	LanguageCode string          `json:"language_code"`
+	Scope        BotCommandScope `json:"scope,omitempty"`
+	LanguageCode string          `json:"language_code,omitempty"`
 }
 
 func (s SetMyCommandsData) Send(b Bot) (Response, error) {
@@ -924,7 +1147,7 @@ func (s SetMyCommandsData) Check() error {
 	if err := s.Scope.checkScope(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Commands": s.Commands})
+	return newValidator(s).err()
 }
 
 // DeleteMyCommandsData deletes the list of the bot's commands for the given scope and user language.
@@ -932,8 +1155,8 @@ func (s SetMyCommandsData) Check() error {
 // Returns True on success.
 type DeleteMyCommandsData struct {
 	// Scope describing scope of users for which the commands are relevant. Defaults to "default".
-	Scope        BotCommandScope `json:"scope"`
-	LanguageCode string          `json:"language_code"`
+	Scope        BotCommandScope `json:"scope,omitempty"`
+	LanguageCode string          `json:"language_code,omitempty"`
 }
 
 func (d DeleteMyCommandsData) Send(b Bot) (Response, error) {
@@ -947,8 +1170,8 @@ func (d DeleteMyCommandsData) Check() error {
 // Returns Array of BotCommand on success. If commands aren't set, an empty list is returned.
 type GetMyCommandsData struct {
 	// Scope describing scope of users for which the commands are relevant. Defaults to "default".
-	Scope        BotCommandScope `json:"scope"`
-	LanguageCode string          `json:"language_code"`
+	Scope        BotCommandScope `json:"scope,omitempty"`
+	LanguageCode string          `json:"language_code,omitempty"`
 }
 
 func (g GetMyCommandsData) Send(b Bot) (Response, error) {
@@ -962,14 +1185,14 @@ func (g GetMyCommandsData) Check() error {
 // On success, if the edited message is not an inline message, the edited Message is returned,
 // otherwise True is returned.
 type EditMessageTextData struct {
-	Text                  string          `json:"text"`
-	InlineMessageId       string          `json:"inline_message_id"`
-	ChatId                int             `json:"chat_id"`
-	MessageId             int             `json:"message_id"`
-	ParseMode             string          `json:"parse_mode"`
-	Entities              []MessageEntity `json:"entities"`
-	DisableWebPagePreview bool            `json:"disable_web_page_preview"`
-	InlineKeyboard
+	Text                  string          `json:"text" check:"required"`
+	InlineMessageId       string          `json:"inline_message_id,omitempty"`
+	ChatId                int             `json:"chat_id,omitempty"`
+	MessageId             int             `json:"message_id,omitempty"`
+	ParseMode             string          `json:"parse_mode,omitempty"`
+	Entities              []MessageEntity `json:"entities,omitempty"`
+	DisableWebPagePreview bool            `json:"disable_web_page_preview,omitempty"`
+	InlineKeyboard        `json:"reply_markup,omitempty"`
 }
 
 func (e EditMessageTextData) Send(b Bot) (Response, error) {
@@ -982,20 +1205,23 @@ func (e EditMessageTextData) Check() error {
 				"ChatId, otherwise set InlineMessageId")
 		}
 	}
-	return globalEmptyFieldChecker(map[string]any{"Text": e.Text, "ParseMode": e.ParseMode})
+	val := newValidator(e)
+	val.textLength("Text", e.Text, e.ParseMode, 1, MaxTextLength)
+	val.inlineKeyboard("InlineKeyboard", e.InlineKeyboard)
+	return val.err()
 }
 
 // EditMessageCaptionData edits captions of messages.
 // On success, if the edited message is not an inline message, the edited Message is returned,
 // otherwise True is returned.
 type EditMessageCaptionData struct {
-	ChatId          int             `json:"chat_id"`
-	MessageId       int             `json:"message_id"`
-	InlineMessageId string          `json:"inline_message_id"`
-	Caption         string          `json:"caption"`
-	ParseMode       string          `json:"parse_mode"`
-	CaptionEntities []MessageEntity `json:"caption_entities"`
-	InlineKeyboard
+	ChatId          int             `json:"chat_id,omitempty"`
+	MessageId       int             `json:"message_id,omitempty"`
+	InlineMessageId string          `json:"inline_message_id,omitempty"`
+	Caption         string          `json:"caption,omitempty"`
+	ParseMode       string          `json:"parse_mode,omitempty"`
+	CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
+	InlineKeyboard  `json:"reply_markup,omitempty"`
 }
 
 func (e EditMessageCaptionData) Send(b Bot) (Response, error) {
@@ -1008,17 +1234,20 @@ func (e EditMessageCaptionData) Check() error {
 				"ChatId, otherwise set InlineMessageId")
 		}
 	}
-	return globalEmptyFieldChecker(map[string]any{"ParseMode": e.ParseMode})
+	val := newValidator(e)
+	val.textLength("Caption", e.Caption, e.ParseMode, 0, MaxCaptionLength)
+	val.inlineKeyboard("InlineKeyboard", e.InlineKeyboard)
+	return val.err()
 }
 
 // EditMessageReplyMarkupData edits only the reply markup of messages.
 // On success, if the edited message is not an inline message, the edited Message is returned,
 // otherwise True is returned.
 type EditMessageReplyMarkupData struct {
-	ChatId          int    `json:"chat_id"`
-	MessageId       int    `json:"message_id"`
-	InlineMessageId string `json:"inline_message_id"`
-	InlineKeyboard
+	ChatId          int    `json:"chat_id,omitempty"`
+	MessageId       int    `json:"message_id,omitempty"`
+	InlineMessageId string `json:"inline_message_id,omitempty"`
+	InlineKeyboard  `json:"reply_markup,omitempty"`
 }
 
 func (e EditMessageReplyMarkupData) Send(b Bot) (Response, error) {
@@ -1036,16 +1265,16 @@ func (e EditMessageReplyMarkupData) Check() error {
 
 // StopPollData stops a poll which was sent by the bot. On success, the stopped Poll is returned.
 type StopPollData struct {
-	ChatId    int `json:"chat_id"`
-	MessageId int `json:"message_id"`
-	InlineKeyboard
+	ChatId         int `json:"chat_id" check:"required"`
+	MessageId      int `json:"message_id" check:"required"`
+	InlineKeyboard `json:"reply_markup,omitempty"`
 }
 
 func (s StopPollData) Send(b Bot) (Response, error) {
 	return Request("stopPoll", b, s, &ResponseImpl{Result: &Poll{}})
 }
 func (s StopPollData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "MessageId": s.MessageId})
+	return newValidator(s).err()
 }
 
 // EditMessageMediaData edits animation, audio, document, photo, or video messages.
@@ -1056,18 +1285,18 @@ func (s StopPollData) Check() error {
 // message, the edited Message is returned, otherwise True is returned.
 type EditMessageMediaData struct {
 	// Required if ChatId and MessageId are not specified. Identifier of the inline message
-	InlineMessageId string `json:"inline_message_id"`
+	InlineMessageId string `json:"inline_message_id,omitempty"`
 	// pass InputMediaPhoto, InputMediaVideo, InputMediaDocument, InputMediaAudio or InputMediaAnimation
-	Media InputMedia `json:"media"`
+	Media InputMedia `json:"media,omitempty"`
 	// Required if InlineMessageId is not specified.
 	// Unique identifier for the target chat or username of the target channel (in the format @channelusername)
-	ChatId int `json:"chat_id"`
+	ChatId int `json:"chat_id,omitempty"`
 	// Required if InlineMessageId is not specified. Identifier of the message to edit
-	MessageId int `json:"message_id"`
+	MessageId int `json:"message_id,omitempty"`
 	// Do Not change this field. It automatically will be set by EditMessageMediaData.Send
 	// using returnFile of InputMedia
-	Files []*os.File
-	InlineKeyboard
+	Files          []*os.File
+	InlineKeyboard `json:"reply_markup,omitempty"`
 }
 
 func (e EditMessageMediaData) Send(b Bot) (Response, error) {
@@ -1097,29 +1326,29 @@ func (e EditMessageMediaData) Check() error {
 // URL, e.g. https://www.example.com/<token>. Since nobody else knows your bot's token, you can be pretty sure it's us.
 type SetWebhookData struct {
 	// HTTPS url to send updates to. Use an empty string to remove webhook integration
-	Url                string   `json:"url"`
-	Certificate        *os.File `json:"certificate"`
-	IpAddress          string   `json:"ip_address"`
-	MaxConnections     int      `json:"max_connections"`
-	AllowedUpdates     []string `json:"allowed_updates"`
-	DropPendingUpdates bool     `json:"drop_pending_updates"`
+	Url                string   `json:"url" check:"required"`
+	Certificate        *os.File `json:"certificate,omitempty"`
+	IpAddress          string   `json:"ip_address,omitempty"`
+	MaxConnections     int      `json:"max_connections,omitempty"`
+	AllowedUpdates     []string `json:"allowed_updates,omitempty"`
+	DropPendingUpdates bool     `json:"drop_pending_updates,omitempty"`
 }
 
 func (s SetWebhookData) Send(b Bot) (Response, error) {
 	return Request("setWebhook", b, s, &ResponseImpl{})
 }
 func (s SetWebhookData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Url": s.Url})
+	return newValidator(s).err()
 }
 
 // SendStickerData sends static .WEBP, animated .TGS, or video .WEBM stickers.
 // On success, the sent Message is returned.
 type SendStickerData struct {
-	ChatId                   int `json:"chat_id"`
-	Sticker                  `json:"sticker"`
-	DisableNotification      bool `json:"disable_notification"`
-	ReplyToMessageId         int  `json:"reply_To_Message_Id"`
-	AllowSendingWithoutReply bool `json:"allow_sending_without_reply"`
+	ChatId                   int `json:"chat_id" check:"required"`
+	Sticker                  `json:"sticker" check:"required"`
+	DisableNotification      bool `json:"disable_notification,omitempty"`
+	ReplyToMessageId         int  `json:"reply_To_Message_Id,omitempty"`
+	AllowSendingWithoutReply bool `json:"allow_sending_without_reply,omitempty"`
 	Keyboard
 }
 
@@ -1127,24 +1356,24 @@ func (s SendStickerData) Send(b Bot) (Response, error) {
 	return Request("sendSticker", b, s, &ResponseImpl{Result: &Message{}})
 }
 func (s SendStickerData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "Sticker": s.Sticker})
+	return newValidator(s).err()
 }
 
 // DeleteStickerFromSetData deletes a sticker from a set created by the bot. Returns True on success.
 type DeleteStickerFromSetData struct {
-	Sticker string `json:"sticker"`
+	Sticker string `json:"sticker" check:"required"`
 }
 
 func (d DeleteStickerFromSetData) Send(b Bot) (Response, error) {
 	return Request("deleteStickerFromSet", b, d, &ResponseImpl{})
 }
 func (d DeleteStickerFromSetData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Sticker": d.Sticker})
+	return newValidator(d).err()
 }
 
 // SetStickerPositionInSetData moves a sticker in a set created by the bot to a specific position.
 type SetStickerPositionInSetData struct {
-	Sticker  string `json:"sticker"`
+	Sticker  string `json:"sticker" check:"required"`
 	Position int    `json:"position"`
 }
 
@@ -1152,48 +1381,48 @@ func (s SetStickerPositionInSetData) Send(b Bot) (Response, error) {
 	return Request("setStickerPositionInSet", b, s, &ResponseImpl{})
 }
 func (s SetStickerPositionInSetData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Sticker": s.Sticker, "Position": s.Position})
+	return newValidator(s).err()
 }
 
 // UploadStickerFileData uploads a .PNG file with a sticker for later use in createNewStickerSet and addStickerToSet
 // methods (can be used multiple times). Returns the uploaded File on success.
 type UploadStickerFileData struct {
-	UserId     int      `json:"user_id"`
-	PngSticker *os.File `json:"png_sticker"`
+	UserId     int      `json:"user_id" check:"required"`
+	PngSticker *os.File `json:"png_sticker" check:"required"`
 }
 
 func (u UploadStickerFileData) Send(b Bot) (Response, error) {
 	return Request("uploadStickerFile", b, u, &ResponseImpl{Result: &File{}})
 }
 func (u UploadStickerFileData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"UserId": u.UserId, "PngSticker": u.PngSticker})
+	return newValidator(u).err()
 }
 
 // GetStickerSetData gets a sticker set. On success, a StickerSet object is returned.
 type GetStickerSetData struct {
-	Name string `json:"name"`
+	Name string `json:"name" check:"required"`
 }
 
 func (g GetStickerSetData) Send(b Bot) (Response, error) {
 	return Request("getStickerSet", b, g, &ResponseImpl{Result: &StickerSet{}})
 }
 func (g GetStickerSetData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"Name": g.Name})
+	return newValidator(g).err()
 }
 
 // CreateNewStickerSetData creates a new sticker set owned by a user. The bot will be able to edit the
 // sticker set thus created. You must use exactly one of the fields PngSticker, TgsSticker, or WebmSticker.
 // Returns True on success.
 type CreateNewStickerSetData struct {
-	UserId        int          `json:"user_id"`
-	Name          string       `json:"name"`
-	Title         string       `json:"title"`
-	Emojis        string       `json:"emojis"`
-	PngSticker    any          `json:"png_sticker"`
-	TgsSticker    *os.File     `json:"tgs_sticker"`
-	WebmSticker   *os.File     `json:"webm_sticker"`
-	ContainsMasks bool         `json:"contains_masks"`
-	MaskPosition  MaskPosition `json:"mask_position"`
+	UserId        int          `json:"user_id" check:"required"`
+	Name          string       `json:"name" check:"required"`
+	Title         string       `json:"title" check:"required"`
+	Emojis        string       `json:"emojis" check:"required"`
+	PngSticker    any          `json:"png_sticker,omitempty"`
+	TgsSticker    *os.File     `json:"tgs_sticker,omitempty"`
+	WebmSticker   *os.File     `json:"webm_sticker,omitempty"`
+	ContainsMasks bool         `json:"contains_masks,omitempty"`
+	MaskPosition  MaskPosition `json:"mask_position,omitempty"`
 }
 
 func (c CreateNewStickerSetData) Send(b Bot) (Response, error) {
@@ -1213,8 +1442,7 @@ func (c CreateNewStickerSetData) Check() error {
 	if set != 1 {
 		return errors.New("you must use exactly one of the fields PngSticker, TgsSticker, or WebmSticker")
 	}
-	return globalEmptyFieldChecker(map[string]any{"UserId": c.UserId, "Name": c.Name,
-		"Title": c.Title, "Emojis": c.Emojis})
+	return newValidator(c).err()
 }
 
 // AddStickerToSetData adds a new sticker to a set created by the bot.
@@ -1223,13 +1451,13 @@ func (c CreateNewStickerSetData) Check() error {
 // Animated sticker sets can have up to 50 stickers. Static sticker sets can have up to 120 stickers.
 // Returns True on success.
 type AddStickerToSetData struct {
-	UserId       int          `json:"user_id"`
-	Name         string       `json:"name"`
-	Emojis       string       `json:"emojis"`
-	PngSticker   any          `json:"png_sticker"`
-	TgsSticker   *os.File     `json:"tgs_sticker"`
-	WebmSticker  *os.File     `json:"webm_sticker"`
-	MaskPosition MaskPosition `json:"mask_position"`
+	UserId       int          `json:"user_id" check:"required"`
+	Name         string       `json:"name" check:"required"`
+	Emojis       string       `json:"emojis" check:"required"`
+	PngSticker   any          `json:"png_sticker,omitempty"`
+	TgsSticker   *os.File     `json:"tgs_sticker,omitempty"`
+	WebmSticker  *os.File     `json:"webm_sticker,omitempty"`
+	MaskPosition MaskPosition `json:"mask_position,omitempty"`
 }
 
 func (a AddStickerToSetData) Send(b Bot) (Response, error) {
@@ -1249,35 +1477,40 @@ func (a AddStickerToSetData) Check() error {
 	if set != 1 {
 		return errors.New("you must use exactly one of the fields PngSticker, TgsSticker, or WebmSticker")
 	}
-	return globalEmptyFieldChecker(map[string]any{"UserId": a.UserId, "Name": a.Name, "Emojis": a.Emojis})
+	return newValidator(a).err()
 }
 
 // SetStickerSetThumbData sets the thumbnail of a sticker set.
 // Animated thumbnails can be set for animated sticker sets only.
 // Video thumbnails can be set only for video sticker sets only. Returns True on success.
 type SetStickerSetThumbData struct {
-	UserId int    `json:"user_id"`
-	Name   string `json:"name"`
-	Thumb  any    `json:"thumb"`
+	UserId int    `json:"user_id" check:"required"`
+	Name   string `json:"name" check:"required"`
+	Thumb  any    `json:"thumb,omitempty"`
 }
 
 func (s SetStickerSetThumbData) Send(b Bot) (Response, error) {
 	return Request("setStickerSetThumb", b, s, &ResponseImpl{})
 }
 func (s SetStickerSetThumbData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"UserId": s.UserId, "Name": s.Name})
+	return newValidator(s).err()
 }
 
+// MaxInlineQueryResults is the maximum number of results of an answer to an inline query.
+const MaxInlineQueryResults = 50
+
 // AnswerInlineQueryData sends answers to an inline query. On success, True is returned.
 // No more than 50 results per query are allowed.
 type AnswerInlineQueryData struct {
-	InlineQueryId     string        `json:"inline_query_id"`
-	Results           []QueryAnswer `json:"results"`
-	CacheTime         int           `json:"cache_time"`
-	IsPersonal        bool          `json:"is_personal"`
-	NextOffset        string        `json:"next_offset"`
-	SwitchPmText      string        `json:"switch_pm_text"`
-	SwitchPmParameter string        `json:"switch_pm_parameter"`
+	InlineQueryId string        `json:"inline_query_id" check:"required"`
+	Results       []QueryAnswer `json:"results,omitempty"`
+	// CacheTime is how long in seconds the results may be cached on telegram servers. If it is nil,
+	// telegram caches them for 300 seconds.
+	CacheTime         *int   `json:"cache_time,omitempty"`
+	IsPersonal        bool   `json:"is_personal,omitempty"`
+	NextOffset        string `json:"next_offset,omitempty"`
+	SwitchPmText      string `json:"switch_pm_text,omitempty"`
+	SwitchPmParameter string `json:"switch_pm_parameter,omitempty"`
 }
 
 func (a AnswerInlineQueryData) Send(b Bot) (Response, error) {
@@ -1293,25 +1526,29 @@ func (a AnswerInlineQueryData) Check() error {
 			return e
 		}
 	}
-	return globalEmptyFieldChecker(map[string]any{"InlineQueryId": a.InlineQueryId})
+	val := newValidator(a)
+	if len(a.Results) > MaxInlineQueryResults {
+		val.add("Results", "must have at most 50 results, got "+strconv.Itoa(len(a.Results)))
+	}
+	return val.err()
 }
 
 // SendGameData sends a game. On success, the sent Message is returned.
 type SendGameData struct {
-	ChatId                   int    `json:"chat_id"`
-	GameShortName            string `json:"game_short_name"`
-	DisableNotification      bool   `json:"disable_notification"`
-	ProtectContent           bool   `json:"protect_content"`
-	ReplyToMessageId         int    `json:"reply_to_message_id"`
-	AllowSendingWithoutReply bool   `json:"allow_sending_without_reply"`
-	InlineKeyboard
+	ChatId                   int    `json:"chat_id" check:"required"`
+	GameShortName            string `json:"game_short_name" check:"required"`
+	DisableNotification      bool   `json:"disable_notification,omitempty"`
+	ProtectContent           bool   `json:"protect_content,omitempty"`
+	ReplyToMessageId         int    `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply bool   `json:"allow_sending_without_reply,omitempty"`
+	InlineKeyboard           `json:"reply_markup,omitempty"`
 }
 
 func (s SendGameData) Send(b Bot) (Response, error) {
 	return Request("sendGame", b, s, &ResponseImpl{Result: &Message{}})
 }
 func (s SendGameData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "GameShortName": s.GameShortName})
+	return newValidator(s).err()
 }
 
 // SetGameScoreData sets the score of the specified user in a game message.
@@ -1319,13 +1556,13 @@ func (s SendGameData) Check() error {
 // otherwise True is returned. Returns an error, if the new score is not greater
 // than the user's current score in the chat and force is False.
 type SetGameScoreData struct {
-	UserId             int    `json:"user_id"`
+	UserId             int    `json:"user_id" check:"required"`
 	Score              int    `json:"score"`
-	Force              bool   `json:"force"`
-	DisableEditMessage bool   `json:"disable_edit_message"`
-	ChatId             int    `json:"chat_id"`
-	MessageId          int    `json:"message_id"`
-	InlineMessageId    string `json:"inline_message_id"`
+	Force              bool   `json:"force,omitempty"`
+	DisableEditMessage bool   `json:"disable_edit_message,omitempty"`
+	ChatId             int    `json:"chat_id,omitempty"`
+	MessageId          int    `json:"message_id,omitempty"`
+	InlineMessageId    string `json:"inline_message_id,omitempty"`
 }
 
 func (s SetGameScoreData) Send(b Bot) (Response, error) {
@@ -1339,24 +1576,24 @@ func (s SetGameScoreData) Check() error {
 				"ChatId, otherwise set InlineMessageId")
 		}
 	}
-	return globalEmptyFieldChecker(map[string]any{"UserId": s.UserId, "Score": s.Score})
+	return newValidator(s).err()
 }
 
 // GetGameHighScoresData Use this method to get data for high score tables.
 // Will return the score of the specified user and several of their neighbors in a game.
-// On success, returns an Array of GameHighScore objects.
+// On success, returns a *[]GameHighScore.
 // This method will currently return scores for the target user, plus two of their closest
 // neighbors on each side. Will also return the top three users if the user and his neighbors are not among them.
 // Please note that this behavior is subject to change.
 type GetGameHighScoresData struct {
-	UserId          int    `json:"user_id"`
-	ChatId          int    `json:"chat_id"`
-	MessageId       int    `json:"message_id"`
-	InlineMessageId string `json:"inline_message_id"`
+	UserId          int    `json:"user_id" check:"required"`
+	ChatId          int    `json:"chat_id,omitempty"`
+	MessageId       int    `json:"message_id,omitempty"`
+	InlineMessageId string `json:"inline_message_id,omitempty"`
 }
 
 func (g GetGameHighScoresData) Send(b Bot) (Response, error) {
-	return Request("getGameHighScores", b, g, &ResponseImpl{})
+	return Request("getGameHighScores", b, g, &ResponseImpl{Result: &[]GameHighScore{}})
 }
 
 func (g GetGameHighScoresData) Check() error {
@@ -1366,38 +1603,38 @@ func (g GetGameHighScoresData) Check() error {
 				"ChatId, otherwise set InlineMessageId")
 		}
 	}
-	return globalEmptyFieldChecker(map[string]any{"UserId": g.UserId})
+	return newValidator(g).err()
 }
 
 // SendInvoiceData sends invoices. On success, the sent Message is returned.
 type SendInvoiceData struct {
-	ChatId                    int            `json:"chat_id"`
-	Title                     string         `json:"title"`
-	Description               string         `json:"description"`
-	Payload                   string         `json:"payload"`
-	ProviderToken             string         `json:"provider_token"`
-	Currency                  string         `json:"currency"`
-	Prices                    []LabeledPrice `json:"prices"`
-	MaxTipAmount              int            `json:"max_tip_amount"`
-	SuggestedTipAmounts       []int          `json:"suggested_tip_amounts"`
-	StartParameter            string         `json:"start_parameter"`
-	ProviderData              string         `json:"provider_data"`
-	PhotoUrl                  string         `json:"photo_url"`
-	PhotoSize                 int            `json:"photo_size"`
-	PhotoWidth                int            `json:"photo_width"`
-	PhotoHeight               int            `json:"photo_height"`
-	NeedName                  bool           `json:"need_name"`
-	NeedPhoneNumber           bool           `json:"need_phone_number"`
-	NeedEmail                 bool           `json:"need_email"`
-	NeedShippingAddress       bool           `json:"need_shipping_address"`
-	SendPhoneNumberToProvider bool           `json:"send_phone_number_to_provider"`
-	SendEmailToProvider       bool           `json:"send_email_to_provider"`
-	IsFlexible                bool           `json:"is_flexible"`
-	DisableNotification       bool           `json:"disable_notification"`
-	ProtectContent            bool           `json:"protect_content"`
-	ReplyToMessageId          int            `json:"reply_to_message_id"`
-	AllowSendingWithoutReply  bool           `json:"allow_sending_without_reply"`
-	InlineKeyboard
+	ChatId                    int            `json:"chat_id" check:"required"`
+	Title                     string         `json:"title" check:"required"`
+	Description               string         `json:"description" check:"required"`
+	Payload                   string         `json:"payload" check:"required"`
+	ProviderToken             string         `json:"provider_token" check:"required"`
+	Currency                  string         `json:"currency" check:"required"`
+	Prices                    []LabeledPrice `json:"prices" check:"required"`
+	MaxTipAmount              int            `json:"max_tip_amount,omitempty"`
+	SuggestedTipAmounts       []int          `json:"suggested_tip_amounts,omitempty"`
+	StartParameter            string         `json:"start_parameter,omitempty"`
+	ProviderData              string         `json:"provider_data,omitempty"`
+	PhotoUrl                  string         `json:"photo_url,omitempty"`
+	PhotoSize                 int            `json:"photo_size,omitempty"`
+	PhotoWidth                int            `json:"photo_width,omitempty"`
+	PhotoHeight               int            `json:"photo_height,omitempty"`
+	NeedName                  bool           `json:"need_name,omitempty"`
+	NeedPhoneNumber           bool           `json:"need_phone_number,omitempty"`
+	NeedEmail                 bool           `json:"need_email,omitempty"`
+	NeedShippingAddress       bool           `json:"need_shipping_address,omitempty"`
+	SendPhoneNumberToProvider bool           `json:"send_phone_number_to_provider,omitempty"`
+	SendEmailToProvider       bool           `json:"send_email_to_provider,omitempty"`
+	IsFlexible                bool           `json:"is_flexible,omitempty"`
+	DisableNotification       bool           `json:"disable_notification,omitempty"`
+	ProtectContent            bool           `json:"protect_content,omitempty"`
+	ReplyToMessageId          int            `json:"reply_to_message_id,omitempty"`
+	AllowSendingWithoutReply  bool           `json:"allow_sending_without_reply,omitempty"`
+	InlineKeyboard            `json:"reply_markup,omitempty"`
 }
 
 func (s SendInvoiceData) Send(b Bot) (Response, error) {
@@ -1405,26 +1642,64 @@ func (s SendInvoiceData) Send(b Bot) (Response, error) {
 }
 
 func (s SendInvoiceData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "Title": s.Title,
-		"Description": s.Description, "Payload": s.Payload, "ProviderToken": s.ProviderToken,
-		"Currency": s.Currency, "Prices": s.Prices})
+	val := newValidator(s)
+	val.tips(s.MaxTipAmount, s.SuggestedTipAmounts)
+	return val.err()
+}
+
+// CreateInvoiceLinkData creates a link for an invoice. On success, the link is returned as a *string.
+type CreateInvoiceLinkData struct {
+	Title                     string         `json:"title" check:"required"`
+	Description               string         `json:"description" check:"required"`
+	Payload                   string         `json:"payload" check:"required"`
+	ProviderToken             string         `json:"provider_token" check:"required"`
+	Currency                  string         `json:"currency" check:"required"`
+	Prices                    []LabeledPrice `json:"prices" check:"required"`
+	MaxTipAmount              int            `json:"max_tip_amount,omitempty"`
+	SuggestedTipAmounts       []int          `json:"suggested_tip_amounts,omitempty"`
+	ProviderData              string         `json:"provider_data,omitempty"`
+	PhotoUrl                  string         `json:"photo_url,omitempty"`
+	PhotoSize                 int            `json:"photo_size,omitempty"`
+	PhotoWidth                int            `json:"photo_width,omitempty"`
+	PhotoHeight               int            `json:"photo_height,omitempty"`
+	NeedName                  bool           `json:"need_name,omitempty"`
+	NeedPhoneNumber           bool           `json:"need_phone_number,omitempty"`
+	NeedEmail                 bool           `json:"need_email,omitempty"`
+	NeedShippingAddress       bool           `json:"need_shipping_address,omitempty"`
+	SendPhoneNumberToProvider bool           `json:"send_phone_number_to_provider,omitempty"`
+	SendEmailToProvider       bool           `json:"send_email_to_provider,omitempty"`
+	IsFlexible                bool           `json:"is_flexible,omitempty"`
+}
+
+func (c CreateInvoiceLinkData) Send(b Bot) (Response, error) {
+	return Request("createInvoiceLink", b, c, &ResponseImpl{Result: new(string)})
+}
+
+func (c CreateInvoiceLinkData) Check() error {
+	val := newValidator(c)
+	val.tips(c.MaxTipAmount, c.SuggestedTipAmounts)
+	return val.err()
 }
 
 // AnswerShippingQueryData replies to shipping queries.
 // If you sent an invoice requesting a shipping address and the parameter is_flexible was specified,
 // the Bot API will send an Update with a shipping_query field to the bot. On success, True is returned.
 type AnswerShippingQueryData struct {
-	ShippingQueryId string            `json:"shipping_query_id"`
+	ShippingQueryId string            `json:"shipping_query_id" check:"required"`
 	Ok              bool              `json:"ok"`
-	ShippingOptions []ShippingOptions `json:"shipping_options"`
-	ErrorMessage    string            `json:"error_message"`
+	ShippingOptions []ShippingOptions `json:"shipping_options,omitempty"`
+	ErrorMessage    string            `json:"error_message,omitempty"`
 }
 
 func (a AnswerShippingQueryData) Send(b Bot) (Response, error) {
 	return Request("answerShippingQuery", b, a, &ResponseImpl{})
 }
 func (a AnswerShippingQueryData) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"ShippingQueryId": a.ShippingQueryId, "Ok": a.Ok})
+	val := newValidator(a)
+	if !a.Ok && a.ErrorMessage == "" {
+		val.add("ErrorMessage", "is required when Ok is false")
+	}
+	return val.err()
 }
 
 // AnswerPreCheckoutQuery responds to pre-checkout queries.
@@ -1432,14 +1707,18 @@ func (a AnswerShippingQueryData) Check() error {
 // in the form of an Update with the field pre_checkout_query. On success, True is returned.
 // Note: The Bot API must receive an answer within 10 seconds after the pre-checkout query was sent.
 type AnswerPreCheckoutQuery struct {
-	PreCheckoutQueryId string `json:"pre_checkout_query_id"`
+	PreCheckoutQueryId string `json:"pre_checkout_query_id" check:"required"`
 	Ok                 bool   `json:"ok"`
-	ErrorMessage       string `json:"error_message"`
+	ErrorMessage       string `json:"error_message,omitempty"`
 }
 
 func (a AnswerPreCheckoutQuery) Send(b Bot) (Response, error) {
 	return Request("answerPreCheckoutQuery", b, a, &ResponseImpl{})
 }
 func (a AnswerPreCheckoutQuery) Check() error {
-	return globalEmptyFieldChecker(map[string]any{"PreCheckoutQueryId": a.PreCheckoutQueryId, "Ok": a.Ok})
+	val := newValidator(a)
+	if !a.Ok && a.ErrorMessage == "" {
+		val.add("ErrorMessage", "is required when Ok is false")
+	}
+	return val.err()
 }
@@ -0,0 +1,222 @@
+package gogram
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// MessageCommand is a bot command found in a message, e.g. "/start@my_bot payload".
+type MessageCommand struct {
+	// Command without the leading '/' and the bot username, e.g. "start"
+	Command string
+	// BotUsername is the username after '@' if the command was addressed to a specific bot
+	BotUsername string
+	// Args is the text after the command, up to the next command or the end of the message, with
+	// leading and trailing spaces removed
+	Args string
+}
+
+// TextLink is a clickable text in a message that opens Url.
+type TextLink struct {
+	Text string
+	Url  string
+}
+
+// EntityText returns the part of text that e points to. Offsets of entities are in UTF-16 code units,
+// so slicing text directly with them doesn't work for texts with emoji or other non-ASCII characters.
+func EntityText(text string, e MessageEntity) string {
+	units := utf16.Encode([]rune(text))
+	start, end := clampEntity(e, len(units))
+	return string(utf16.Decode(units[start:end]))
+}
+
+// clampEntity returns start and end of e, limited to a text of length units.
+func clampEntity(e MessageEntity, length int) (int, int) {
+	start, end := e.Offset, e.Offset+e.Length
+	if start < 0 {
+		start = 0
+	}
+	if end > length {
+		end = length
+	}
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+// textAndEntities returns Text and Entities of the message, or Caption and CaptionEntities if it has no text.
+func (m Message) textAndEntities() (string, []MessageEntity) {
+	if m.Text != "" {
+		return m.Text, m.Entities
+	}
+	return m.Caption, m.CaptionEntities
+}
+
+// EntityText returns the text of e in the message text (or caption, if the message has no text).
+func (m Message) EntityText(e MessageEntity) string {
+	text, _ := m.textAndEntities()
+	return EntityText(text, e)
+}
+
+// entitiesOfType returns text of all entities of type t.
+func (m Message) entitiesOfType(t string) []string {
+	text, entities := m.textAndEntities()
+	var result []string
+	for _, e := range entities {
+		if e.Type == t {
+			result = append(result, EntityText(text, e))
+		}
+	}
+	return result
+}
+
+// Commands returns all bot commands of the message in order, with their arguments.
+func (m Message) Commands() []MessageCommand {
+	text, entities := m.textAndEntities()
+	units := utf16.Encode([]rune(text))
+	var commands []MessageCommand
+	var ends []int
+	for _, e := range entities {
+		if e.Type != "bot_command" {
+			continue
+		}
+		start, end := clampEntity(e, len(units))
+		command := strings.TrimPrefix(string(utf16.Decode(units[start:end])), "/")
+		c := MessageCommand{Command: command}
+		if i := strings.Index(command, "@"); i != -1 {
+			c.Command, c.BotUsername = command[:i], command[i+1:]
+		}
+		if len(commands) != 0 {
+			// arguments of the previous command end where this command starts
+			commands[len(commands)-1].Args = strings.TrimSpace(string(utf16.Decode(units[ends[len(ends)-1]:start])))
+		}
+		commands = append(commands, c)
+		ends = append(ends, end)
+	}
+	if len(commands) != 0 {
+		commands[len(commands)-1].Args = strings.TrimSpace(string(utf16.Decode(units[ends[len(ends)-1]:])))
+	}
+	return commands
+}
+
+// Mentions returns all @username mentions of the message, including the '@'.
+func (m Message) Mentions() []string {
+	return m.entitiesOfType("mention")
+}
+
+// TextMentions returns users mentioned in the message who don't have a username.
+func (m Message) TextMentions() []User {
+	_, entities := m.textAndEntities()
+	var users []User
+	for _, e := range entities {
+		if e.Type == "text_mention" && e.User != nil {
+			users = append(users, *e.User)
+		}
+	}
+	return users
+}
+
+// Hashtags returns all hashtags of the message, including the '#'.
+func (m Message) Hashtags() []string {
+	return m.entitiesOfType("hashtag")
+}
+
+// Urls returns all urls written in the text of the message.
+func (m Message) Urls() []string {
+	return m.entitiesOfType("url")
+}
+
+// TextLinks returns all clickable texts of the message that open a url.
+func (m Message) TextLinks() []TextLink {
+	text, entities := m.textAndEntities()
+	var links []TextLink
+	for _, e := range entities {
+		if e.Type == "text_link" {
+			links = append(links, TextLink{Text: EntityText(text, e), Url: e.Url})
+		}
+	}
+	return links
+}
+
+// HTML returns the text (or caption) of the message formatted for HTML parse mode, so it can be sent again
+// with the same formatting.
+func (m Message) HTML() string {
+	return HTML(m.Fragments()...)
+}
+
+// MarkdownV2 returns the text (or caption) of the message formatted for MarkdownV2 parse mode, so it can be
+// sent again with the same formatting.
+func (m Message) MarkdownV2() string {
+	return MarkdownV2(m.Fragments()...)
+}
+
+// Fragments converts the text (or caption) of the message and its entities to fragments.
+func (m Message) Fragments() []Fragment {
+	text, entities := m.textAndEntities()
+	return EntityFragments(text, entities)
+}
+
+// EntityFragments converts a text and its entities to fragments. Entities that are only detected by
+// telegram (e.g. urls, mentions and hashtags) become plain text, since telegram detects them again.
+func EntityFragments(text string, entities []MessageEntity) []Fragment {
+	units := utf16.Encode([]rune(text))
+	sorted := make([]MessageEntity, len(entities))
+	copy(sorted, entities)
+	// outer entities come before inner ones that start at the same offset
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Offset != sorted[j].Offset {
+			return sorted[i].Offset < sorted[j].Offset
+		}
+		return sorted[i].Length > sorted[j].Length
+	})
+	return buildFragments(units, 0, len(units), sorted)
+}
+
+// buildFragments builds fragments of units[start:end]. entities must be sorted and inside [start, end).
+func buildFragments(units []uint16, start, end int, entities []MessageEntity) []Fragment {
+	var fragments []Fragment
+	pos := start
+	for i := 0; i < len(entities); {
+		e := entities[i]
+		eStart, eEnd := clampEntity(e, end)
+		if eStart < pos || eStart >= eEnd {
+			// overlapping or empty entity; telegram doesn't produce them, so they are ignored
+			i++
+			continue
+		}
+		if pos < eStart {
+			fragments = append(fragments, Plain(string(utf16.Decode(units[pos:eStart]))))
+		}
+		j := i + 1
+		for j < len(entities) && entities[j].Offset < eEnd {
+			j++
+		}
+		children := buildFragments(units, eStart, eEnd, entities[i+1:j])
+		fragments = append(fragments, entityFragment(e, string(utf16.Decode(units[eStart:eEnd])), children))
+		pos, i = eEnd, j
+	}
+	if pos < end {
+		fragments = append(fragments, Plain(string(utf16.Decode(units[pos:end]))))
+	}
+	return fragments
+}
+
+func entityFragment(e MessageEntity, text string, children []Fragment) Fragment {
+	switch e.Type {
+	case "bold", "italic", "underline", "strikethrough", "spoiler":
+		return Fragment{entity: e.Type, children: children}
+	case "code":
+		return Code(text)
+	case "pre":
+		return Pre(text, e.Language)
+	case "text_link":
+		return Link(e.Url, children...)
+	case "text_mention":
+		if e.User != nil {
+			return Mention(*e.User, children...)
+		}
+	}
+	return Fragment{children: children}
+}
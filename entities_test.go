@@ -0,0 +1,49 @@
+package gogram
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMessage_Commands(t *testing.T) {
+	m := Message{Text: "🎉 /start@my_bot  a b /help x",
+		Entities: []MessageEntity{{Type: "bot_command", Offset: 3, Length: 13},
+			{Type: "bot_command", Offset: 22, Length: 5}}}
+	want := []MessageCommand{{Command: "start", BotUsername: "my_bot", Args: "a b"}, {Command: "help", Args: "x"}}
+	if got := m.Commands(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMessage_EntityHelpers(t *testing.T) {
+	m := Message{Caption: "😀😀 @user #tag https://a.b here",
+		CaptionEntities: []MessageEntity{{Type: "mention", Offset: 5, Length: 5},
+			{Type: "hashtag", Offset: 11, Length: 4}, {Type: "url", Offset: 16, Length: 11},
+			{Type: "text_link", Offset: 28, Length: 4, Url: "https://c.d"}}}
+	if got := m.Mentions(); !reflect.DeepEqual(got, []string{"@user"}) {
+		t.Errorf("unexpected mentions %v", got)
+	}
+	if got := m.Hashtags(); !reflect.DeepEqual(got, []string{"#tag"}) {
+		t.Errorf("unexpected hashtags %v", got)
+	}
+	if got := m.Urls(); !reflect.DeepEqual(got, []string{"https://a.b"}) {
+		t.Errorf("unexpected urls %v", got)
+	}
+	if got := m.TextLinks(); !reflect.DeepEqual(got, []TextLink{{Text: "here", Url: "https://c.d"}}) {
+		t.Errorf("unexpected text links %v", got)
+	}
+}
+
+func TestMessage_HTML(t *testing.T) {
+	text, entities := Entities(Plain("👍 <"), Bold(Plain("b "), Italic(Plain("bi"))), Plain(" "),
+		Link("https://a.b", Plain("l")), Plain(" "), Code("x<y"))
+	m := Message{Text: text, Entities: entities}
+	want := `👍 &lt;<b>b <i>bi</i></b> <a href="https://a.b">l</a> <code>x&lt;y</code>`
+	if got := m.HTML(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	want = "👍 <*b _bi_* [l](https://a.b) `x<y`"
+	if got := m.MarkdownV2(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,271 @@
+package gogram
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Parse modes accepted by ParseMode fields of data structs.
+const (
+	ParseModeHTML       = "HTML"
+	ParseModeMarkdownV2 = "MarkdownV2"
+	ParseModeMarkdown   = "Markdown"
+)
+
+// Fragment is a piece of a formatted message. Fragments are created with Plain, Bold, Italic, Underline,
+// Strikethrough, Spoiler, Code, Pre, Link and Mention, and they can be nested (e.g. Bold(Plain("a "), Italic(Plain("b")))).
+// A list of fragments can be rendered with HTML or MarkdownV2, in which case user-provided text is escaped
+// automatically, or with Entities to get plain text and a []MessageEntity for Entities or CaptionEntities fields.
+type Fragment struct {
+	// entity is the MessageEntity type of the fragment. it is empty for plain text.
+	entity   string
+	text     string
+	url      string
+	user     User
+	language string
+	children []Fragment
+}
+
+// Plain returns a fragment of unformatted text.
+func Plain(text string) Fragment {
+	return Fragment{text: text}
+}
+
+// Bold returns a bold fragment of children.
+func Bold(children ...Fragment) Fragment {
+	return Fragment{entity: "bold", children: children}
+}
+
+// Italic returns an italic fragment of children.
+func Italic(children ...Fragment) Fragment {
+	return Fragment{entity: "italic", children: children}
+}
+
+// Underline returns an underlined fragment of children.
+func Underline(children ...Fragment) Fragment {
+	return Fragment{entity: "underline", children: children}
+}
+
+// Strikethrough returns a strikethrough fragment of children.
+func Strikethrough(children ...Fragment) Fragment {
+	return Fragment{entity: "strikethrough", children: children}
+}
+
+// Spoiler returns a spoiler fragment of children.
+func Spoiler(children ...Fragment) Fragment {
+	return Fragment{entity: "spoiler", children: children}
+}
+
+// Code returns an inline fixed-width code fragment. Code fragments can't contain other fragments.
+func Code(text string) Fragment {
+	return Fragment{entity: "code", text: text}
+}
+
+// Pre returns a pre-formatted fixed-width code block. language is optional.
+func Pre(text, language string) Fragment {
+	return Fragment{entity: "pre", text: text, language: language}
+}
+
+// Link returns a fragment that opens url when tapped.
+func Link(url string, children ...Fragment) Fragment {
+	return Fragment{entity: "text_link", url: url, children: children}
+}
+
+// Mention returns a fragment that mentions user. It works for users without a username too.
+func Mention(user User, children ...Fragment) Fragment {
+	return Fragment{entity: "text_mention", user: user, children: children}
+}
+
+// EscapeHTML escapes text so that it can be used in a message with HTML parse mode.
+func EscapeHTML(text string) string {
+	return htmlEscaper.Replace(text)
+}
+
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// EscapeMarkdownV2 escapes text so that it can be used in a message with MarkdownV2 parse mode.
+// Don't use it for the content of code blocks or urls; they have their own rules.
+func EscapeMarkdownV2(text string) string {
+	return escapeMarkdownV2(text, "_*[]()~`>#+-=|{}.!\\")
+}
+
+// escapeMarkdownV2 prepends a '\' to every character of text that exists in special.
+func escapeMarkdownV2(text, special string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune(special, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// HTML renders fragments as a text for HTML parse mode.
+func HTML(fragments ...Fragment) string {
+	var b strings.Builder
+	for _, f := range fragments {
+		f.writeHTML(&b)
+	}
+	return b.String()
+}
+
+// MarkdownV2 renders fragments as a text for MarkdownV2 parse mode.
+func MarkdownV2(fragments ...Fragment) string {
+	var b strings.Builder
+	for _, f := range fragments {
+		f.writeMarkdownV2(&b)
+	}
+	return b.String()
+}
+
+// Format renders fragments for parseMode, which must be either ParseModeHTML or ParseModeMarkdownV2.
+func Format(parseMode string, fragments ...Fragment) (string, error) {
+	switch parseMode {
+	case ParseModeHTML:
+		return HTML(fragments...), nil
+	case ParseModeMarkdownV2:
+		return MarkdownV2(fragments...), nil
+	}
+	return "", errors.New("parse mode must be HTML or MarkdownV2")
+}
+
+// Entities renders fragments as plain text and the entities describing its formatting. Offsets and lengths
+// of entities are in UTF-16 code units, as telegram expects them. Use the result for Text and Entities
+// (or Caption and CaptionEntities) fields and leave ParseMode empty.
+func Entities(fragments ...Fragment) (string, []MessageEntity) {
+	var b strings.Builder
+	var entities []MessageEntity
+	offset := 0
+	for _, f := range fragments {
+		f.writeEntities(&b, &entities, &offset)
+	}
+	return b.String(), entities
+}
+
+func (f Fragment) writeHTML(b *strings.Builder) {
+	var open, closing string
+	switch f.entity {
+	case "bold":
+		open, closing = "<b>", "</b>"
+	case "italic":
+		open, closing = "<i>", "</i>"
+	case "underline":
+		open, closing = "<u>", "</u>"
+	case "strikethrough":
+		open, closing = "<s>", "</s>"
+	case "spoiler":
+		open, closing = "<tg-spoiler>", "</tg-spoiler>"
+	case "code":
+		open, closing = "<code>", "</code>"
+	case "pre":
+		open, closing = "<pre>", "</pre>"
+		if f.language != "" {
+			open, closing = `<pre><code class="language-`+EscapeHTML(f.language)+`">`, "</code></pre>"
+		}
+	case "text_link":
+		open, closing = `<a href="`+EscapeHTML(f.url)+`">`, "</a>"
+	case "text_mention":
+		open, closing = `<a href="tg://user?id=`+strconv.Itoa(f.user.Id)+`">`, "</a>"
+	}
+	b.WriteString(open)
+	b.WriteString(EscapeHTML(f.text))
+	for _, c := range f.children {
+		c.writeHTML(b)
+	}
+	b.WriteString(closing)
+}
+
+func (f Fragment) writeMarkdownV2(b *strings.Builder) {
+	switch f.entity {
+	case "code":
+		b.WriteString("`" + escapeMarkdownV2(f.text, "`\\") + "`")
+		return
+	case "pre":
+		b.WriteString("```" + f.language + "\n" + escapeMarkdownV2(f.text, "`\\") + "\n```")
+		return
+	}
+	var inner strings.Builder
+	inner.WriteString(EscapeMarkdownV2(f.text))
+	for _, c := range f.children {
+		c.writeMarkdownV2(&inner)
+	}
+	content := inner.String()
+	switch f.entity {
+	case "bold":
+		b.WriteString("*" + content + "*")
+	case "italic":
+		// "__" is always greedily treated as the beginning or the end of an underline entity, so italic and
+		// underline delimiters next to each other must be separated by a \r, which is ignored by telegram.
+		if strings.HasPrefix(content, "__") {
+			content = "\r" + content
+		}
+		if strings.HasSuffix(content, "__") {
+			content += "\r"
+		}
+		b.WriteString("_" + content + "_")
+	case "underline":
+		if strings.HasSuffix(content, "_") {
+			content += "\r"
+		}
+		b.WriteString("__" + content + "__")
+	case "strikethrough":
+		b.WriteString("~" + content + "~")
+	case "spoiler":
+		b.WriteString("||" + content + "||")
+	case "text_link":
+		b.WriteString("[" + content + "](" + escapeMarkdownV2(f.url, ")\\") + ")")
+	case "text_mention":
+		b.WriteString("[" + content + "](tg://user?id=" + strconv.Itoa(f.user.Id) + ")")
+	default:
+		b.WriteString(content)
+	}
+}
+
+func (f Fragment) writeEntities(b *strings.Builder, entities *[]MessageEntity, offset *int) {
+	start := *offset
+	index := len(*entities)
+	if f.entity != "" {
+		// the entity is added before its children, so it has to be reserved now and filled later
+		*entities = append(*entities, MessageEntity{})
+	}
+	b.WriteString(f.text)
+	*offset += utf16Len(f.text)
+	for _, c := range f.children {
+		c.writeEntities(b, entities, offset)
+	}
+	if f.entity == "" {
+		return
+	}
+	if *offset == start {
+		// telegram doesn't accept empty entities
+		*entities = append((*entities)[:index], (*entities)[index+1:]...)
+		return
+	}
+	e := MessageEntity{Type: f.entity, Offset: start, Length: *offset - start}
+	switch f.entity {
+	case "pre":
+		e.Language = f.language
+	case "text_link":
+		e.Url = f.url
+	case "text_mention":
+		user := f.user
+		e.User = &user
+	}
+	(*entities)[index] = e
+}
+
+// utf16Len returns the length of s in UTF-16 code units, which is how telegram measures texts and entities.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 {
+			// runes outside the basic multilingual plane are encoded as surrogate pairs
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,43 @@
+package gogram
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	got := HTML(Bold(Plain("a<b> & "), Italic(Plain("c"))), Plain(" "), Link(`https://x.y/?a="1"`, Plain("link")),
+		Plain(" "), Pre("if a < b {}", "go"), Mention(User{ReplyAble: ReplyAble{Id: 42}}, Plain("me")))
+	want := `<b>a&lt;b&gt; &amp; <i>c</i></b> <a href="https://x.y/?a=&quot;1&quot;">link</a> ` +
+		`<pre><code class="language-go">if a &lt; b {}</code></pre><a href="tg://user?id=42">me</a>`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownV2(t *testing.T) {
+	got := MarkdownV2(Bold(Plain("1+1=2.")), Plain(" "), Code("a`b\\c"), Plain(" "),
+		Link("https://x.y/(a)", Plain("[x]")), Plain(" "), Spoiler(Strikethrough(Plain("!"))))
+	want := "*1\\+1\\=2\\.* `a\\`b\\\\c` [\\[x\\]](https://x.y/(a\\)) ||~\\!~||"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	got = MarkdownV2(Underline(Italic(Plain("iu"))))
+	want = "___iu_\r__"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestEntities(t *testing.T) {
+	text, entities := Entities(Plain("😀 "), Bold(Plain("bold "), Italic(Plain("it"))), Italic(), Plain(" "),
+		Pre("x", "go"))
+	if text != "😀 bold it x" {
+		t.Errorf("unexpected text %q", text)
+	}
+	want := []MessageEntity{{Type: "bold", Offset: 3, Length: 7}, {Type: "italic", Offset: 8, Length: 2},
+		{Type: "pre", Offset: 11, Length: 1, Language: "go"}}
+	if !reflect.DeepEqual(entities, want) {
+		t.Errorf("got %+v, want %+v", entities, want)
+	}
+}
@@ -0,0 +1,119 @@
+package gogram
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// GameOrigin identifies a game message: either a message in a chat, or an inline message. Scores are set and
+// high scores are read for the game of a message.
+type GameOrigin struct {
+	ChatId          int
+	MessageId       int
+	InlineMessageId string
+}
+
+// MessageGameOrigin returns the origin of a game message that the bot sent to a chat.
+func MessageGameOrigin(m Message) GameOrigin {
+	return GameOrigin{ChatId: m.Chat.Id, MessageId: m.MessageId}
+}
+
+// CallbackGameOrigin returns the origin of the game that a callback query was sent from, which is an inline
+// message if the game was sent in inline mode.
+func CallbackGameOrigin(q CallbackQuery) GameOrigin {
+	var o GameOrigin
+	o.ChatId, o.MessageId, o.InlineMessageId = q.messageIds()
+	return o
+}
+
+// String encodes o as "<chat id>:<message id>", or "i:<inline message id>", e.g. to put it in the url of the
+// game so the game can report scores for its message. Use ParseGameOrigin to decode it.
+func (o GameOrigin) String() string {
+	if o.InlineMessageId != "" {
+		return "i:" + o.InlineMessageId
+	}
+	return strconv.Itoa(o.ChatId) + ":" + strconv.Itoa(o.MessageId)
+}
+
+// ParseGameOrigin decodes an origin encoded with GameOrigin.String.
+func ParseGameOrigin(s string) (GameOrigin, error) {
+	first, second, ok := strings.Cut(s, ":")
+	if !ok || second == "" {
+		return GameOrigin{}, errors.New("invalid game origin " + s)
+	}
+	if first == "i" {
+		return GameOrigin{InlineMessageId: second}, nil
+	}
+	chatId, err := strconv.Atoi(first)
+	if err != nil {
+		return GameOrigin{}, errors.New("invalid game origin " + s)
+	}
+	messageId, err := strconv.Atoi(second)
+	if err != nil {
+		return GameOrigin{}, errors.New("invalid game origin " + s)
+	}
+	return GameOrigin{ChatId: chatId, MessageId: messageId}, nil
+}
+
+// SetScore sets the score of a user in the game of o. ChatId and MessageId, or InlineMessageId of data are
+// set automatically.
+func (o GameOrigin) SetScore(b Bot, data SetGameScoreData) (Response, error) {
+	data.ChatId, data.MessageId, data.InlineMessageId = o.ChatId, o.MessageId, o.InlineMessageId
+	return data.Send(b)
+}
+
+// HighScores returns the high scores of the game of o around userId.
+func (o GameOrigin) HighScores(b Bot, userId int) ([]GameHighScore, error) {
+	data := GetGameHighScoresData{UserId: userId, ChatId: o.ChatId, MessageId: o.MessageId,
+		InlineMessageId: o.InlineMessageId}
+	res, err := data.Send(b)
+	if err != nil {
+		return nil, err
+	}
+	return *res.getResult().(*[]GameHighScore), nil
+}
+
+// GameHandler answers callback queries of "Play" buttons of games with the url of the game, which makes the
+// client open the game.
+type GameHandler struct {
+	// Url returns the url of the game q.GameShortName for q. CallbackGameOrigin(q) can be put in the url, so
+	// the game can report scores for its message. If it returns an error, the error is shown to the user.
+	// This field is mandatory.
+	Url func(q CallbackQuery) (string, error)
+}
+
+// Handle handles q if it is sent by a game, and returns false otherwise.
+func (g GameHandler) Handle(b Bot, q CallbackQuery) (bool, error) {
+	if q.GameShortName == "" {
+		return false, nil
+	}
+	if g.Url == nil {
+		return true, errors.New("Url of GameHandler is not set")
+	}
+	url, err := g.Url(q)
+	if err != nil {
+		_, answerErr := q.Answer(b, AnswerCallbackQueryData{Text: err.Error(), ShowAlert: true})
+		return true, answerErr
+	}
+	_, err = q.Answer(b, AnswerCallbackQueryData{Url: url})
+	return true, err
+}
+
+// Leaderboard renders high scores into fragments of a message, a line for each score like "1. Ada — 120".
+// The line of the user with highlightUserId is bold. Render them with HTML, MarkdownV2 or Entities.
+func Leaderboard(scores []GameHighScore, highlightUserId int) []Fragment {
+	var fragments []Fragment
+	for i, score := range scores {
+		name := strings.TrimSpace(score.User.FirstName + " " + score.User.LastName)
+		line := Plain(strconv.Itoa(score.Position) + ". " + name + " — " + strconv.Itoa(score.Score))
+		if score.User.Id == highlightUserId {
+			line = Bold(line)
+		}
+		if i != 0 {
+			fragments = append(fragments, Plain("\n"))
+		}
+		fragments = append(fragments, line)
+	}
+	return fragments
+}
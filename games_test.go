@@ -0,0 +1,51 @@
+package gogram
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGameOrigin_String(t *testing.T) {
+	for _, o := range []GameOrigin{{ChatId: -100, MessageId: 7}, {InlineMessageId: "AbC:d"}} {
+		parsed, err := ParseGameOrigin(o.String())
+		if err != nil || parsed != o {
+			t.Errorf("ParseGameOrigin(%q) = %+v, %v", o.String(), parsed, err)
+		}
+	}
+	if _, err := ParseGameOrigin("7"); err == nil {
+		t.Error("invalid origin is parsed")
+	}
+}
+
+func TestGameHandler_Handle(t *testing.T) {
+	var contentType string
+	fields := map[string]string{}
+	server := stubServer(t, &contentType, fields)
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	g := GameHandler{Url: func(q CallbackQuery) (string, error) {
+		if q.GameShortName != "snake" {
+			return "", errors.New("unknown game")
+		}
+		return "https://example.com/snake?origin=" + CallbackGameOrigin(q).String(), nil
+	}}
+	q := CallbackQuery{Id: "1", GameShortName: "snake", InlineMessageId: "m"}
+	if handled, err := g.Handle(b, q); !handled || err != nil {
+		t.Fatal(handled, err)
+	}
+	if fields["url"] != "https://example.com/snake?origin=i:m" {
+		t.Errorf("unexpected answer %v", fields)
+	}
+	if handled, _ := g.Handle(b, CallbackQuery{Id: "2", Data: "x"}); handled {
+		t.Error("callback query without a game is handled")
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	ada := User{ReplyAble: ReplyAble{Id: 1, FirstName: "Ada"}}
+	bob := User{ReplyAble: ReplyAble{Id: 2, FirstName: "Bob", LastName: "<B>"}}
+	html := HTML(Leaderboard([]GameHighScore{{Position: 1, User: ada, Score: 120}, {Position: 2, User: bob, Score: 90}}, 2)...)
+	if html != "1. Ada — 120\n<b>2. Bob &lt;B&gt; — 90</b>" {
+		t.Errorf("unexpected leaderboard %q", html)
+	}
+}
@@ -0,0 +1,118 @@
+package gogram
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// InlineQueryCache sits in front of an inline query handler. Telegram sends a new InlineQuery for every
+// keystroke; InlineQueryCache drops queries that are superseded by a newer query of the same user while
+// waiting for Debounce, and caches answers of Handler, so the same query is not handled twice within TTL.
+//
+//	cache := &gogram.InlineQueryCache{TTL: time.Minute, Debounce: 300 * time.Millisecond,
+//		Handler: func(q gogram.InlineQuery) (gogram.AnswerInlineQueryData, error) {
+//			return gogram.AnswerInlineQueryData{Results: search(q.Query, q.Offset)}, nil
+//		}}
+//	_, err := cache.Handle(bot, update.InlineQuery)
+//
+// Debouncing only works if updates are handled concurrently (see Bot.Concurrent).
+type InlineQueryCache struct {
+	// TTL is how long answers are cached. CacheTime of answers is set to TTL too, so telegram caches them
+	// for as long as InlineQueryCache does. Answers are not cached if TTL is 0.
+	TTL time.Duration
+	// Debounce is how long a query waits before it is handled. If the same user sends another query meanwhile,
+	// the former is dropped without an answer.
+	Debounce time.Duration
+	// Personal must be set if answers depend on the user, so answers are cached per user and IsPersonal of
+	// answers is set.
+	Personal bool
+	// Handler returns the answer of q. InlineQueryId of the answer is set automatically.
+	// This field is mandatory.
+	Handler func(q InlineQuery) (AnswerInlineQueryData, error)
+	mu      sync.Mutex
+	answers map[string]cachedAnswer
+	latest  map[int]string
+}
+
+type cachedAnswer struct {
+	data    AnswerInlineQueryData
+	expires time.Time
+}
+
+// key returns the cache key of q: its text and offset, and the user if answers are personal.
+func (c *InlineQueryCache) key(q InlineQuery) string {
+	key := q.Offset + "|" + q.Query
+	if c.Personal {
+		key = strconv.Itoa(q.From.Id) + "|" + key
+	}
+	return key
+}
+
+// superseded waits for Debounce and reports whether the user sent a newer query meanwhile.
+func (c *InlineQueryCache) superseded(q InlineQuery) bool {
+	if c.Debounce <= 0 {
+		return false
+	}
+	c.mu.Lock()
+	if c.latest == nil {
+		c.latest = map[int]string{}
+	}
+	c.latest[q.From.Id] = q.Id
+	c.mu.Unlock()
+	time.Sleep(c.Debounce)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.latest[q.From.Id] != q.Id {
+		return true
+	}
+	delete(c.latest, q.From.Id)
+	return false
+}
+
+// Handle answers q from the cache, or with the answer of Handler. It returns a nil Response and no error if
+// q is superseded by a newer query and is not answered.
+func (c *InlineQueryCache) Handle(b Bot, q InlineQuery) (Response, error) {
+	if c.Handler == nil {
+		return nil, errors.New("Handler of InlineQueryCache is not set")
+	}
+	if c.superseded(q) {
+		return nil, nil
+	}
+	key := c.key(q)
+	c.mu.Lock()
+	cached, ok := c.answers[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return q.Answer(b, cached.data)
+	}
+	data, err := c.Handler(q)
+	if err != nil {
+		return nil, err
+	}
+	cacheTime := int(c.TTL / time.Second)
+	data.CacheTime = &cacheTime
+	data.IsPersonal = c.Personal
+	res, err := q.Answer(b, data)
+	if err == nil && c.TTL > 0 {
+		c.store(key, data)
+	}
+	return res, err
+}
+
+// store caches data with key, and removes expired answers.
+func (c *InlineQueryCache) store(key string, data AnswerInlineQueryData) {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.answers == nil {
+		c.answers = map[string]cachedAnswer{}
+	}
+	for k, a := range c.answers {
+		if now.After(a.expires) {
+			delete(c.answers, k)
+		}
+	}
+	c.answers[key] = cachedAnswer{data: data, expires: now.Add(c.TTL)}
+}
@@ -0,0 +1,59 @@
+package gogram
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestInlineQueryCache_Handle(t *testing.T) {
+	var contentType string
+	fields := map[string]string{}
+	server := stubServer(t, &contentType, fields)
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	calls := 0
+	cache := &InlineQueryCache{TTL: time.Minute, Personal: true,
+		Handler: func(q InlineQuery) (AnswerInlineQueryData, error) {
+			calls++
+			result := NewInlineQueryResultArticle(q.Query, InputTextMessageContent{MessageText: q.Query})
+			result.Id = "1"
+			return AnswerInlineQueryData{Results: []QueryAnswer{result}}, nil
+		}}
+	user := User{ReplyAble: ReplyAble{Id: 5}}
+	for _, id := range []string{"a", "b"} {
+		if _, err := cache.Handle(b, InlineQuery{Id: id, From: user, Query: "cats"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 || fields["inline_query_id"] != "b" || fields["cache_time"] != "60" ||
+		fields["is_personal"] != "true" {
+		t.Errorf("unexpected answer %v after %d calls", fields, calls)
+	}
+	if _, err := cache.Handle(b, InlineQuery{Id: "c", From: User{ReplyAble: ReplyAble{Id: 6}}, Query: "cats"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Error("personal answer is shared between users")
+	}
+}
+
+func TestInlineQueryCache_Debounce(t *testing.T) {
+	cache := &InlineQueryCache{Debounce: 50 * time.Millisecond}
+	user := User{ReplyAble: ReplyAble{Id: 5}}
+	var wg sync.WaitGroup
+	var first bool
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		first = cache.superseded(InlineQuery{Id: "a", From: user})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if cache.superseded(InlineQuery{Id: "b", From: user}) {
+		t.Error("latest query is superseded")
+	}
+	wg.Wait()
+	if !first {
+		t.Error("former query is not superseded")
+	}
+}
@@ -0,0 +1,124 @@
+package gogram
+
+import (
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultInlineResultTTL is how long InlineResultTracker remembers answered results if TTL is not set.
+const DefaultInlineResultTTL = 10 * time.Minute
+
+// TrackedInlineResult is an answered inline query result that was chosen by a user.
+type TrackedInlineResult struct {
+	// Query is the inline query that was answered with Result.
+	Query  InlineQuery
+	Result QueryAnswer
+	Chosen ChosenInlineResult
+}
+
+// InlineResultTracker remembers the results that inline queries are answered with, so a ChosenInlineResult
+// can be matched with the result and the query it came from, e.g. for analytics, or to edit the sent message
+// later with its InlineMessageId. Use NewInlineResultTracker to create one.
+type InlineResultTracker struct {
+	// TTL is how long results are remembered after the query is answered. Default is DefaultInlineResultTTL.
+	TTL     time.Duration
+	mu      sync.Mutex
+	results map[string]trackedResult
+}
+
+type trackedResult struct {
+	query   InlineQuery
+	result  QueryAnswer
+	expires time.Time
+}
+
+func NewInlineResultTracker(ttl time.Duration) *InlineResultTracker {
+	return &InlineResultTracker{TTL: ttl, results: map[string]trackedResult{}}
+}
+
+// inlineResultKey identifies a result by the user, the query text and the result id, since result ids are
+// only unique in the answer of a query.
+func inlineResultKey(userId int, query, resultId string) string {
+	return strconv.Itoa(userId) + "|" + query + "|" + resultId
+}
+
+// Track remembers results that q is answered with. Results must have their Id set.
+func (t *InlineResultTracker) Track(q InlineQuery, results []QueryAnswer) {
+	ttl := t.TTL
+	if ttl == 0 {
+		ttl = DefaultInlineResultTTL
+	}
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.results == nil {
+		t.results = map[string]trackedResult{}
+	}
+	for key, r := range t.results {
+		if now.After(r.expires) {
+			delete(t.results, key)
+		}
+	}
+	for _, result := range results {
+		if id := inlineResultId(result); id != "" {
+			t.results[inlineResultKey(q.From.Id, q.Query, id)] = trackedResult{query: q, result: result,
+				expires: now.Add(ttl)}
+		}
+	}
+}
+
+// Answer answers q with data and tracks the results if the answer is sent successfully.
+func (t *InlineResultTracker) Answer(b Bot, q InlineQuery, data AnswerInlineQueryData) (Response, error) {
+	res, err := q.Answer(b, data)
+	if err == nil {
+		t.Track(q, data.Results)
+	}
+	return res, err
+}
+
+// AnswerPaged answers q like InlineQuery.AnswerPaged, and tracks the page of results with the ids that are set
+// automatically if the answer is sent successfully.
+func (t *InlineResultTracker) AnswerPaged(b Bot, q InlineQuery, results []QueryAnswer,
+	data AnswerInlineQueryData) (Response, error) {
+	data, err := q.pagedAnswer(results, data)
+	if err != nil {
+		return nil, err
+	}
+	return t.Answer(b, q, data)
+}
+
+// AnswerPages answers q like InlineQuery.AnswerPages, and tracks the page of results with the ids that are set
+// automatically if the answer is sent successfully.
+func (t *InlineResultTracker) AnswerPages(b Bot, q InlineQuery, page func(offset, limit int) ([]QueryAnswer, error),
+	data AnswerInlineQueryData) (Response, error) {
+	data, err := q.pagesAnswer(page, data)
+	if err != nil {
+		return nil, err
+	}
+	return t.Answer(b, q, data)
+}
+
+// Chosen returns the tracked result that c refers to, and false if it is unknown or has expired.
+func (t *InlineResultTracker) Chosen(c ChosenInlineResult) (TrackedInlineResult, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r, ok := t.results[inlineResultKey(c.From.Id, c.Query, c.ResultId)]
+	if !ok || time.Now().After(r.expires) {
+		return TrackedInlineResult{}, false
+	}
+	return TrackedInlineResult{Query: r.query, Result: r.result, Chosen: c}, true
+}
+
+// inlineResultId returns the Id field of result, or "" if it has none.
+func inlineResultId(result QueryAnswer) string {
+	v := reflect.Indirect(reflect.ValueOf(result))
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if id := v.FieldByName("Id"); id.IsValid() && id.Kind() == reflect.String {
+		return id.String()
+	}
+	return ""
+}
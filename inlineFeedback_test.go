@@ -0,0 +1,71 @@
+package gogram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUpdate_TypeIndicator(t *testing.T) {
+	var u Update
+	err := json.Unmarshal([]byte(`{"update_id":1,"chosen_inline_result":{"result_id":"3","from":{"id":5},
+		"inline_message_id":"m","query":"cats"}}`), &u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.TypeIndicator() != UpdateTypeChosenInlineResult || u.ChosenInlineResult.InlineMessageId != "m" {
+		t.Errorf("unexpected update %v", u)
+	}
+}
+
+func TestInlineResultTracker(t *testing.T) {
+	tracker := NewInlineResultTracker(time.Minute)
+	q := InlineQuery{Id: "q", From: User{ReplyAble: ReplyAble{Id: 5}}, Query: "cats"}
+	article := NewInlineQueryResultArticle("a", InputTextMessageContent{MessageText: "t"})
+	article.Id = "3"
+	tracker.Track(q, []QueryAnswer{article})
+	r, ok := tracker.Chosen(ChosenInlineResult{ResultId: "3", From: User{ReplyAble: ReplyAble{Id: 5}}, Query: "cats"})
+	if !ok || r.Query.Id != "q" || r.Result != article {
+		t.Errorf("result is not tracked: %v", r)
+	}
+	if _, ok = tracker.Chosen(ChosenInlineResult{ResultId: "3", From: User{ReplyAble: ReplyAble{Id: 6}}, Query: "cats"}); ok {
+		t.Error("result of another user is found")
+	}
+	tracker.TTL = -time.Second
+	tracker.Track(q, []QueryAnswer{article})
+	if _, ok = tracker.Chosen(ChosenInlineResult{ResultId: "3", From: User{ReplyAble: ReplyAble{Id: 5}}, Query: "cats"}); ok {
+		t.Error("expired result is found")
+	}
+}
+
+func TestInlineResultTracker_AnswerPaged(t *testing.T) {
+	var answer struct {
+		Results []struct {
+			Id string `json:"id"`
+		} `json:"results"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&answer); err != nil {
+			t.Error(err)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	tracker := NewInlineResultTracker(time.Minute)
+	q := InlineQuery{Id: "q", From: User{ReplyAble: ReplyAble{Id: 5}}, Query: "cats"}
+	results := []QueryAnswer{NewInlineQueryResultArticle("a", InputTextMessageContent{MessageText: "a"}),
+		NewInlineQueryResultArticle("b", InputTextMessageContent{MessageText: "b"})}
+	if _, err := tracker.AnswerPaged(b, q, results, AnswerInlineQueryData{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(answer.Results) != 2 {
+		t.Fatalf("unexpected answer %+v", answer)
+	}
+	r, ok := tracker.Chosen(ChosenInlineResult{ResultId: answer.Results[1].Id, From: q.From, Query: "cats"})
+	if !ok || r.Result.(*InlineQueryResultArticle).Title != "b" {
+		t.Errorf("result with an automatic id is not tracked: %v", r)
+	}
+}
@@ -11,6 +11,18 @@ type InlineQuery struct {
 	Location Location `json:"location"`
 }
 
+// ChosenInlineResult is a result of an inline query that was chosen by a user and sent to their chat partner.
+// Telegram only sends it when inline feedback is enabled for the bot via @BotFather.
+type ChosenInlineResult struct {
+	ResultId string   `json:"result_id"`
+	From     User     `json:"from"`
+	Location Location `json:"location"`
+	// InlineMessageId is the id of the sent message. It is only set if the result has an inline keyboard,
+	// and can be used to edit the message.
+	InlineMessageId string `json:"inline_message_id"`
+	Query           string `json:"query"`
+}
+
 type QueryAnswer interface {
 	checkQueryAnswer() error
 }
@@ -27,14 +39,14 @@ func (i InputEmptyContent) checkMessageContent() error {
 }
 
 type InputTextMessageContent struct {
-	MessageText           string          `json:"message_text"`
-	ParseMode             string          `json:"parse_mode"`
-	Entities              []MessageEntity `json:"entities"`
-	DisableWebPagePreview bool            `json:"disable_web_page_preview"`
+	MessageText           string          `json:"message_text" check:"required"`
+	ParseMode             string          `json:"parse_mode,omitempty"`
+	Entities              []MessageEntity `json:"entities,omitempty"`
+	DisableWebPagePreview bool            `json:"disable_web_page_preview,omitempty"`
 }
 
 func (i InputTextMessageContent) checkMessageContent() error {
-	return globalEmptyFieldChecker(map[string]any{"MessageText": i.MessageText})
+	return newValidator(i).err()
 }
 
 type InputLocationMessageContent struct {
@@ -50,69 +62,68 @@ func (i InputLocationMessageContent) checkMessageContent() error {
 type InputVenueMessageContent struct {
 	Latitude        float64 `json:"latitude"`
 	Longitude       float64 `json:"longitude"`
-	Title           string  `json:"title"`
-	Address         string  `json:"address"`
-	FoursquareId    string  `json:"foursquare_id"`
-	FoursquareType  string  `json:"foursquare_type"`
-	GooglePlaceId   string  `json:"google_place_id"`
-	GooglePlaceType string  `json:"google_place_type"`
+	Title           string  `json:"title" check:"required"`
+	Address         string  `json:"address" check:"required"`
+	FoursquareId    string  `json:"foursquare_id,omitempty"`
+	FoursquareType  string  `json:"foursquare_type,omitempty"`
+	GooglePlaceId   string  `json:"google_place_id,omitempty"`
+	GooglePlaceType string  `json:"google_place_type,omitempty"`
 }
 
 func (i InputVenueMessageContent) checkMessageContent() error {
-	return globalEmptyFieldChecker(map[string]any{"Title": i.Title, "Address": i.Address})
+	return newValidator(i).err()
 }
 
 type InputContactMessageContent struct {
-	PhoneNumber string `json:"phone_number"`
-	FirstName   string `json:"first_name"`
-	LastName    string `json:"last_name"`
-	Vcard       string `json:"vcard"`
+	PhoneNumber string `json:"phone_number" check:"required"`
+	FirstName   string `json:"first_name" check:"required"`
+	LastName    string `json:"last_name,omitempty"`
+	Vcard       string `json:"vcard,omitempty"`
 }
 
 func (i InputContactMessageContent) checkMessageContent() error {
-	return globalEmptyFieldChecker(map[string]any{"PhoneNumber": i.PhoneNumber, "FirstName": i.FirstName})
+	return newValidator(i).err()
 }
 
 type InputInvoiceMessageContent struct {
-	Title                     string         `json:"title"`
-	Description               string         `json:"description"`
-	Payload                   string         `json:"payload"`
-	ProviderToken             string         `json:"provider_token"`
-	Currency                  string         `json:"currency"`
-	Prices                    []LabeledPrice `json:"prices"`
-	MaxTipAmount              int            `json:"max_tip_amount"`
-	SuggestedTipAmounts       []int          `json:"suggested_tip_amounts"`
-	ProviderData              string         `json:"provider_data"`
-	PhotoUrl                  string         `json:"photo_url"`
-	PhotoSize                 int            `json:"photo_size"`
-	PhotoWidth                int            `json:"photo_width"`
-	PhotoHeight               int            `json:"photo_height"`
-	NeedName                  bool           `json:"need_name"`
-	NeedPhoneNumber           bool           `json:"need_phone_number"`
-	NeedEmail                 bool           `json:"need_email"`
-	NeedShippingAddress       bool           `json:"need_shipping_address"`
-	SendPhoneNumberToProvider bool           `json:"send_phone_number_to_provider"`
-	SendEmailToProvider       bool           `json:"send_email_to_provider"`
-	IsFlexible                bool           `json:"is_flexible"`
+	Title                     string         `json:"title" check:"required"`
+	Description               string         `json:"description" check:"required"`
+	Payload                   string         `json:"payload" check:"required"`
+	ProviderToken             string         `json:"provider_token" check:"required"`
+	Currency                  string         `json:"currency" check:"required"`
+	Prices                    []LabeledPrice `json:"prices" check:"required"`
+	MaxTipAmount              int            `json:"max_tip_amount,omitempty"`
+	SuggestedTipAmounts       []int          `json:"suggested_tip_amounts,omitempty"`
+	ProviderData              string         `json:"provider_data,omitempty"`
+	PhotoUrl                  string         `json:"photo_url,omitempty"`
+	PhotoSize                 int            `json:"photo_size,omitempty"`
+	PhotoWidth                int            `json:"photo_width,omitempty"`
+	PhotoHeight               int            `json:"photo_height,omitempty"`
+	NeedName                  bool           `json:"need_name,omitempty"`
+	NeedPhoneNumber           bool           `json:"need_phone_number,omitempty"`
+	NeedEmail                 bool           `json:"need_email,omitempty"`
+	NeedShippingAddress       bool           `json:"need_shipping_address,omitempty"`
+	SendPhoneNumberToProvider bool           `json:"send_phone_number_to_provider,omitempty"`
+	SendEmailToProvider       bool           `json:"send_email_to_provider,omitempty"`
+	IsFlexible                bool           `json:"is_flexible,omitempty"`
 }
 
 func (i InputInvoiceMessageContent) checkMessageContent() error {
-	return globalEmptyFieldChecker(map[string]any{"Title": i.Title, "Description": i.Description,
-		"Payload": i.Payload, "ProviderData": i.ProviderToken, "Currency": i.Currency, "Prices": i.Prices})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultArticle struct {
 	Type                string         `json:"type"`
-	Id                  string         `json:"id"`
-	Title               string         `json:"title"`
-	InputMessageContent MessageContent `json:"input_message_content"`
-	Url                 string         `json:"url"`
-	HideUrl             bool           `json:"hide_url"`
-	Description         string         `json:"description"`
-	ThumbUrl            string         `json:"thumb_url"`
-	ThumbWidth          int            `json:"thumb_width"`
-	ThumbHeight         int            `json:"thumb_height"`
-	InlineKeyboard
+	Id                  string         `json:"id" check:"required"`
+	Title               string         `json:"title" check:"required"`
+	InputMessageContent MessageContent `json:"input_message_content,omitempty"`
+	Url                 string         `json:"url,omitempty"`
+	HideUrl             bool           `json:"hide_url,omitempty"`
+	Description         string         `json:"description,omitempty"`
+	ThumbUrl            string         `json:"thumb_url,omitempty"`
+	ThumbWidth          int            `json:"thumb_width,omitempty"`
+	ThumbHeight         int            `json:"thumb_height,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i InlineQueryResultArticle) checkQueryAnswer() error {
@@ -122,24 +133,24 @@ func (i InlineQueryResultArticle) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Title": i.Title, "Id": i.Id})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultPhoto struct {
 	Type                string          `json:"type"`
-	Id                  string          `json:"id"`
-	PhotoUrl            string          `json:"photo_url"`
-	PhotoFileId         string          `json:"photo_file_id"`
-	ThumbUrl            string          `json:"thumb_url"`
-	PhotoWidth          int             `json:"photo_width"`
-	PhotoHeight         int             `json:"photo_height"`
-	Title               string          `json:"title"`
-	Description         string          `json:"description"`
-	Caption             string          `json:"caption"`
-	ParseMode           string          `json:"parse_mode"`
-	CaptionEntities     []MessageEntity `json:"caption_entities"`
-	InputMessageContent MessageContent  `json:"input_message_content"`
-	InlineKeyboard
+	Id                  string          `json:"id" check:"required"`
+	PhotoUrl            string          `json:"photo_url,omitempty"`
+	PhotoFileId         string          `json:"photo_file_id,omitempty"`
+	ThumbUrl            string          `json:"thumb_url,omitempty"`
+	PhotoWidth          int             `json:"photo_width,omitempty"`
+	PhotoHeight         int             `json:"photo_height,omitempty"`
+	Title               string          `json:"title,omitempty"`
+	Description         string          `json:"description,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultPhoto) checkQueryAnswer() error {
@@ -159,25 +170,25 @@ func (i *InlineQueryResultPhoto) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultGif struct {
 	Type                string          `json:"type"`
-	Id                  string          `json:"id"`
-	GifUrl              string          `json:"gif_url"`
-	GifFileId           string          `json:"gif_File_Id"`
-	GifWidth            int             `json:"gif_width"`
-	GifHeight           int             `json:"gif_height"`
-	GifDuration         int             `json:"gif_duration"`
-	ThumbUrl            string          `json:"thumb_url"`
-	ThumbMimeType       string          `json:"thumb_mime_type"`
-	Title               string          `json:"title"`
-	Caption             string          `json:"caption"`
-	ParseMode           string          `json:"parse_mode"`
-	CaptionEntities     []MessageEntity `json:"caption_entities"`
-	InputMessageContent MessageContent  `json:"input_message_content"`
-	InlineKeyboard
+	Id                  string          `json:"id" check:"required"`
+	GifUrl              string          `json:"gif_url,omitempty"`
+	GifFileId           string          `json:"gif_file_id,omitempty"`
+	GifWidth            int             `json:"gif_width,omitempty"`
+	GifHeight           int             `json:"gif_height,omitempty"`
+	GifDuration         int             `json:"gif_duration,omitempty"`
+	ThumbUrl            string          `json:"thumb_url,omitempty"`
+	ThumbMimeType       string          `json:"thumb_mime_type,omitempty"`
+	Title               string          `json:"title,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultGif) checkQueryAnswer() error {
@@ -197,25 +208,25 @@ func (i *InlineQueryResultGif) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultMpeg4Gif struct {
 	Type                string          `json:"type"`
-	Id                  string          `json:"id"`
-	Mpeg4Url            string          `json:"mpeg4_url"`
-	Mpeg4FileId         string          `json:"mpeg4_file_id"`
-	Mpeg4Width          int             `json:"mpeg4_width"`
-	Mpeg4Height         int             `json:"mpeg4_height"`
-	Mpeg4Duration       int             `json:"mpeg4_duration"`
-	ThumbUrl            string          `json:"thumb_url"`
-	ThumbMimeType       string          `json:"thumb_mime_type"`
-	Title               string          `json:"title"`
-	Caption             string          `json:"caption"`
-	ParseMode           string          `json:"parse_mode"`
-	CaptionEntities     []MessageEntity `json:"caption_entities"`
-	InputMessageContent MessageContent  `json:"input_message_content"`
-	InlineKeyboard
+	Id                  string          `json:"id" check:"required"`
+	Mpeg4Url            string          `json:"mpeg4_url,omitempty"`
+	Mpeg4FileId         string          `json:"mpeg4_file_id,omitempty"`
+	Mpeg4Width          int             `json:"mpeg4_width,omitempty"`
+	Mpeg4Height         int             `json:"mpeg4_height,omitempty"`
+	Mpeg4Duration       int             `json:"mpeg4_duration,omitempty"`
+	ThumbUrl            string          `json:"thumb_url,omitempty"`
+	ThumbMimeType       string          `json:"thumb_mime_type,omitempty"`
+	Title               string          `json:"title,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultMpeg4Gif) checkQueryAnswer() error {
@@ -235,26 +246,26 @@ func (i *InlineQueryResultMpeg4Gif) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultVideo struct {
 	Type                string          `json:"type"`
-	Id                  string          `json:"id"`
-	VideoUrl            string          `json:"video_url"`
-	VideoFileId         string          `json:"video_file_id"`
-	MimeType            string          `json:"mime_type"`
-	ThumbUrl            string          `json:"thumb_url"`
-	Title               string          `json:"title"`
-	Caption             string          `json:"caption"`
-	ParseMode           string          `json:"parse_mode"`
-	CaptionEntities     []MessageEntity `json:"caption_entities"`
-	VideoWidth          int             `json:"video_width"`
-	VideoHeight         int             `json:"video_height"`
-	VideoDuration       int             `json:"video_duration"`
-	Description         string          `json:"description"`
-	InputMessageContent MessageContent  `json:"input_message_content"`
-	InlineKeyboard
+	Id                  string          `json:"id" check:"required"`
+	VideoUrl            string          `json:"video_url,omitempty"`
+	VideoFileId         string          `json:"video_file_id,omitempty"`
+	MimeType            string          `json:"mime_type,omitempty"`
+	ThumbUrl            string          `json:"thumb_url,omitempty"`
+	Title               string          `json:"title" check:"required"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	VideoWidth          int             `json:"video_width,omitempty"`
+	VideoHeight         int             `json:"video_height,omitempty"`
+	VideoDuration       int             `json:"video_duration,omitempty"`
+	Description         string          `json:"description,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultVideo) checkQueryAnswer() error {
@@ -278,22 +289,22 @@ func (i *InlineQueryResultVideo) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id, "Title": i.Title})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultAudio struct {
 	Type                string          `json:"type"`
-	Id                  string          `json:"id"`
-	AudioUrl            string          `json:"audio_url"`
-	AudioFileId         string          `json:"audio_file_id"`
-	Title               string          `json:"title"`
-	Caption             string          `json:"caption"`
-	ParseMode           string          `json:"parse_mode"`
-	CaptionEntities     []MessageEntity `json:"caption_entities"`
-	Performer           string          `json:"performer"`
-	AudioDuration       int             `json:"audio_duration"`
-	InputMessageContent MessageContent  `json:"input_message_content"`
-	InlineKeyboard
+	Id                  string          `json:"id" check:"required"`
+	AudioUrl            string          `json:"audio_url,omitempty"`
+	AudioFileId         string          `json:"audio_file_id,omitempty"`
+	Title               string          `json:"title,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	Performer           string          `json:"performer,omitempty"`
+	AudioDuration       int             `json:"audio_duration,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultAudio) checkQueryAnswer() error {
@@ -313,21 +324,21 @@ func (i *InlineQueryResultAudio) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultVoice struct {
 	Type                string          `json:"type"`
-	Id                  string          `json:"id"`
-	VoiceUrl            string          `json:"voice_url"`
-	VoiceFileId         string          `json:"voice_file_id"`
-	Title               string          `json:"title"`
-	Caption             string          `json:"caption"`
-	ParseMode           string          `json:"parse_mode"`
-	CaptionEntities     []MessageEntity `json:"caption_entities"`
-	VoiceDuration       int             `json:"voice_duration"`
-	InputMessageContent MessageContent  `json:"input_message_content"`
-	InlineKeyboard
+	Id                  string          `json:"id" check:"required"`
+	VoiceUrl            string          `json:"voice_url,omitempty"`
+	VoiceFileId         string          `json:"voice_file_id,omitempty"`
+	Title               string          `json:"title,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	VoiceDuration       int             `json:"voice_duration,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultVoice) checkQueryAnswer() error {
@@ -344,25 +355,25 @@ func (i *InlineQueryResultVoice) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultDocument struct {
 	Type                string          `json:"type"`
-	Id                  string          `json:"id"`
-	Title               string          `json:"title"`
-	Caption             string          `json:"caption"`
-	ParseMode           string          `json:"parse_mode"`
-	CaptionEntities     []MessageEntity `json:"caption_entities"`
-	DocumentUrl         string          `json:"document_url"`
-	DocumentFileId      string          `json:"document_file_id"`
-	MimeType            string          `json:"mime_type"`
-	Description         string          `json:"description"`
-	InputMessageContent MessageContent  `json:"input_message_content"`
-	ThumbUrl            string          `json:"thumb_url"`
-	ThumbWidth          int             `json:"thumb_width"`
-	ThumbHeight         int             `json:"thumb_height"`
-	InlineKeyboard
+	Id                  string          `json:"id" check:"required"`
+	Title               string          `json:"title,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	DocumentUrl         string          `json:"document_url,omitempty"`
+	DocumentFileId      string          `json:"document_file_id,omitempty"`
+	MimeType            string          `json:"mime_type,omitempty"`
+	Description         string          `json:"description,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	ThumbUrl            string          `json:"thumb_url,omitempty"`
+	ThumbWidth          int             `json:"thumb_width,omitempty"`
+	ThumbHeight         int             `json:"thumb_height,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultDocument) checkQueryAnswer() error {
@@ -386,19 +397,19 @@ func (i *InlineQueryResultDocument) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultLocation struct {
 	Type                string         `json:"type"`
-	Id                  string         `json:"id"`
-	Title               string         `json:"title"`
-	InputMessageContent MessageContent `json:"input_message_content"`
-	ThumbUrl            string         `json:"thumb_url"`
-	ThumbWidth          int            `json:"thumb_width"`
-	ThumbHeight         int            `json:"thumb_height"`
+	Id                  string         `json:"id" check:"required"`
+	Title               string         `json:"title" check:"required"`
+	InputMessageContent MessageContent `json:"input_message_content,omitempty"`
+	ThumbUrl            string         `json:"thumb_url,omitempty"`
+	ThumbWidth          int            `json:"thumb_width,omitempty"`
+	ThumbHeight         int            `json:"thumb_height,omitempty"`
 	Location
-	InlineKeyboard
+	InlineKeyboard `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultLocation) checkQueryAnswer() error {
@@ -407,25 +418,25 @@ func (i *InlineQueryResultLocation) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id, "Title": i.Title})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultVenue struct {
 	Type                string         `json:"type"`
-	Id                  string         `json:"id"`
+	Id                  string         `json:"id" check:"required"`
 	Latitude            float64        `json:"latitude"`
 	Longitude           float64        `json:"longitude"`
-	Title               string         `json:"title"`
-	Address             string         `json:"address"`
-	FoursquareId        string         `json:"foursquare_id"`
-	FoursquareType      string         `json:"foursquare_type"`
-	GooglePlaceId       string         `json:"google_place_id"`
-	GooglePlaceType     string         `json:"google_place_type"`
-	InputMessageContent MessageContent `json:"input_message_content"`
-	ThumbUrl            string         `json:"thumb_url"`
-	ThumbWidth          int            `json:"thumb_width"`
-	ThumbHeight         int            `json:"thumb_height"`
-	InlineKeyboard
+	Title               string         `json:"title" check:"required"`
+	Address             string         `json:"address" check:"required"`
+	FoursquareId        string         `json:"foursquare_id,omitempty"`
+	FoursquareType      string         `json:"foursquare_type,omitempty"`
+	GooglePlaceId       string         `json:"google_place_id,omitempty"`
+	GooglePlaceType     string         `json:"google_place_type,omitempty"`
+	InputMessageContent MessageContent `json:"input_message_content,omitempty"`
+	ThumbUrl            string         `json:"thumb_url,omitempty"`
+	ThumbWidth          int            `json:"thumb_width,omitempty"`
+	ThumbHeight         int            `json:"thumb_height,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultVenue) checkQueryAnswer() error {
@@ -434,21 +445,21 @@ func (i *InlineQueryResultVenue) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id, "Title": i.Title, "Address": i.Address})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultContact struct {
 	Type                string         `json:"type"`
-	Id                  string         `json:"id"`
-	PhoneNumber         string         `json:"phone_number"`
-	FirstName           string         `json:"first_name"`
-	LastName            string         `json:"last_name"`
-	Vcard               string         `json:"vcard"`
-	InputMessageContent MessageContent `json:"input_message_content"`
-	ThumbUrl            string         `json:"thumb_url"`
-	ThumbWidth          int            `json:"thumb_width"`
-	ThumbHeight         int            `json:"thumb_height"`
-	InlineKeyboard
+	Id                  string         `json:"id" check:"required"`
+	PhoneNumber         string         `json:"phone_number,omitempty"`
+	FirstName           string         `json:"first_name" check:"required"`
+	LastName            string         `json:"last_name,omitempty"`
+	Vcard               string         `json:"vcard,omitempty"`
+	InputMessageContent MessageContent `json:"input_message_content,omitempty"`
+	ThumbUrl            string         `json:"thumb_url,omitempty"`
+	ThumbWidth          int            `json:"thumb_width,omitempty"`
+	ThumbHeight         int            `json:"thumb_height,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i *InlineQueryResultContact) checkQueryAnswer() error {
@@ -457,30 +468,158 @@ func (i *InlineQueryResultContact) checkQueryAnswer() error {
 	} else if err := i.InputMessageContent.checkMessageContent(); err != nil {
 		return err
 	}
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id, "FirstName": i.FirstName})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultGame struct {
-	Type          string `json:"type"`
-	Id            string `json:"id"`
-	GameShortName string `json:"game_short_name"`
-	InlineKeyboard
+	Type           string `json:"type"`
+	Id             string `json:"id" check:"required"`
+	GameShortName  string `json:"game_short_name" check:"required"`
+	InlineKeyboard `json:"reply_markup,omitempty"`
 }
 
 func (i InlineQueryResultGame) checkQueryAnswer() error {
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id, "GameShortName": i.GameShortName})
+	return newValidator(i).err()
 }
 
 type InlineQueryResultSticker struct {
 	Type                string         `json:"type"`
-	Id                  string         `json:"id"`
-	StickerFileId       string         `json:"sticker_file_id"`
-	InputMessageContent MessageContent `json:"input_message_content"`
-	InlineKeyboard
+	Id                  string         `json:"id" check:"required"`
+	StickerFileId       string         `json:"sticker_file_id" check:"required"`
+	InputMessageContent MessageContent `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
 }
 
 func (i InlineQueryResultSticker) checkQueryAnswer() error {
-	return globalEmptyFieldChecker(map[string]any{"Id": i.Id, "StickerFileId": i.StickerFileId})
+	return newValidator(i).err()
+}
+
+// Cached results are files that are already stored on telegram servers, sent by their file id.
+
+type InlineQueryResultCachedPhoto struct {
+	Type                string          `json:"type"`
+	Id                  string          `json:"id" check:"required"`
+	PhotoFileId         string          `json:"photo_file_id" check:"required"`
+	Title               string          `json:"title,omitempty"`
+	Description         string          `json:"description,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
+}
+
+func (i InlineQueryResultCachedPhoto) checkQueryAnswer() error {
+	return checkCachedResult(i, i.InputMessageContent, i.Caption, i.ParseMode)
+}
+
+type InlineQueryResultCachedGif struct {
+	Type                string          `json:"type"`
+	Id                  string          `json:"id" check:"required"`
+	GifFileId           string          `json:"gif_file_id" check:"required"`
+	Title               string          `json:"title,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
+}
+
+func (i InlineQueryResultCachedGif) checkQueryAnswer() error {
+	return checkCachedResult(i, i.InputMessageContent, i.Caption, i.ParseMode)
+}
+
+type InlineQueryResultCachedMpeg4Gif struct {
+	Type                string          `json:"type"`
+	Id                  string          `json:"id" check:"required"`
+	Mpeg4FileId         string          `json:"mpeg4_file_id" check:"required"`
+	Title               string          `json:"title,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
+}
+
+func (i InlineQueryResultCachedMpeg4Gif) checkQueryAnswer() error {
+	return checkCachedResult(i, i.InputMessageContent, i.Caption, i.ParseMode)
+}
+
+type InlineQueryResultCachedVideo struct {
+	Type                string          `json:"type"`
+	Id                  string          `json:"id" check:"required"`
+	VideoFileId         string          `json:"video_file_id" check:"required"`
+	Title               string          `json:"title" check:"required"`
+	Description         string          `json:"description,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
+}
+
+func (i InlineQueryResultCachedVideo) checkQueryAnswer() error {
+	return checkCachedResult(i, i.InputMessageContent, i.Caption, i.ParseMode)
+}
+
+type InlineQueryResultCachedAudio struct {
+	Type                string          `json:"type"`
+	Id                  string          `json:"id" check:"required"`
+	AudioFileId         string          `json:"audio_file_id" check:"required"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
+}
+
+func (i InlineQueryResultCachedAudio) checkQueryAnswer() error {
+	return checkCachedResult(i, i.InputMessageContent, i.Caption, i.ParseMode)
+}
+
+type InlineQueryResultCachedVoice struct {
+	Type                string          `json:"type"`
+	Id                  string          `json:"id" check:"required"`
+	VoiceFileId         string          `json:"voice_file_id" check:"required"`
+	Title               string          `json:"title" check:"required"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
+}
+
+func (i InlineQueryResultCachedVoice) checkQueryAnswer() error {
+	return checkCachedResult(i, i.InputMessageContent, i.Caption, i.ParseMode)
+}
+
+type InlineQueryResultCachedDocument struct {
+	Type                string          `json:"type"`
+	Id                  string          `json:"id" check:"required"`
+	DocumentFileId      string          `json:"document_file_id" check:"required"`
+	Title               string          `json:"title" check:"required"`
+	Description         string          `json:"description,omitempty"`
+	Caption             string          `json:"caption,omitempty"`
+	ParseMode           string          `json:"parse_mode,omitempty"`
+	CaptionEntities     []MessageEntity `json:"caption_entities,omitempty"`
+	InputMessageContent MessageContent  `json:"input_message_content,omitempty"`
+	InlineKeyboard      `json:"reply_markup,omitempty"`
+}
+
+func (i InlineQueryResultCachedDocument) checkQueryAnswer() error {
+	return checkCachedResult(i, i.InputMessageContent, i.Caption, i.ParseMode)
+}
+
+// checkCachedResult checks the required fields, the message content and the caption of a cached result.
+func checkCachedResult(result any, content MessageContent, caption, parseMode string) error {
+	if content != nil {
+		if err := content.checkMessageContent(); err != nil {
+			return err
+		}
+	}
+	val := newValidator(result)
+	val.textLength("Caption", caption, parseMode, 0, MaxCaptionLength)
+	return val.err()
 }
 
 func (i InlineQuery) Answer(b Bot, data AnswerInlineQueryData) (response Response, err error) {
@@ -0,0 +1,227 @@
+package gogram
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// Constructors of inline query results. They set Type and the required fields; Id can be left empty when
+// results are answered with InlineQuery.AnswerPaged or InlineQuery.AnswerPages, which set it automatically.
+
+// NewInlineQueryResultArticle creates an article result, which sends content when it is chosen.
+func NewInlineQueryResultArticle(title string, content MessageContent) *InlineQueryResultArticle {
+	return &InlineQueryResultArticle{Type: "article", Title: title, InputMessageContent: content}
+}
+
+// NewInlineQueryResultPhoto creates a result of the JPEG photo at photoUrl, with the thumbnail at thumbUrl.
+func NewInlineQueryResultPhoto(photoUrl, thumbUrl string) *InlineQueryResultPhoto {
+	return &InlineQueryResultPhoto{Type: "photo", PhotoUrl: photoUrl, ThumbUrl: thumbUrl}
+}
+
+// NewInlineQueryResultGif creates a result of the animated GIF at gifUrl, with the thumbnail at thumbUrl.
+func NewInlineQueryResultGif(gifUrl, thumbUrl string) *InlineQueryResultGif {
+	return &InlineQueryResultGif{Type: "gif", GifUrl: gifUrl, ThumbUrl: thumbUrl}
+}
+
+// NewInlineQueryResultMpeg4Gif creates a result of the soundless MPEG-4 animation at mpeg4Url, shown with the
+// thumbnail at thumbUrl.
+func NewInlineQueryResultMpeg4Gif(mpeg4Url, thumbUrl string) *InlineQueryResultMpeg4Gif {
+	return &InlineQueryResultMpeg4Gif{Type: "mpeg4_gif", Mpeg4Url: mpeg4Url, ThumbUrl: thumbUrl}
+}
+
+// NewInlineQueryResultVideo creates a video result. mimeType is "text/html" or "video/mp4".
+func NewInlineQueryResultVideo(title, videoUrl, mimeType, thumbUrl string) *InlineQueryResultVideo {
+	return &InlineQueryResultVideo{Type: "video", Title: title, VideoUrl: videoUrl, MimeType: mimeType,
+		ThumbUrl: thumbUrl}
+}
+
+// NewInlineQueryResultAudio creates a result of the MP3 file at audioUrl.
+func NewInlineQueryResultAudio(title, audioUrl string) *InlineQueryResultAudio {
+	return &InlineQueryResultAudio{Type: "audio", Title: title, AudioUrl: audioUrl}
+}
+
+// NewInlineQueryResultVoice creates a result of the OGG voice recording at voiceUrl.
+func NewInlineQueryResultVoice(title, voiceUrl string) *InlineQueryResultVoice {
+	return &InlineQueryResultVoice{Type: "voice", Title: title, VoiceUrl: voiceUrl}
+}
+
+// NewInlineQueryResultDocument creates a document result. mimeType is "application/pdf" or "application/zip".
+func NewInlineQueryResultDocument(title, documentUrl, mimeType string) *InlineQueryResultDocument {
+	return &InlineQueryResultDocument{Type: "document", Title: title, DocumentUrl: documentUrl, MimeType: mimeType}
+}
+
+// NewInlineQueryResultLocation creates a result that sends a location.
+func NewInlineQueryResultLocation(title string, latitude, longitude float64) *InlineQueryResultLocation {
+	return &InlineQueryResultLocation{Type: "location", Title: title,
+		Location: Location{Latitude: latitude, Longitude: longitude}}
+}
+
+// NewInlineQueryResultVenue creates a result that sends a venue.
+func NewInlineQueryResultVenue(title, address string, latitude, longitude float64) *InlineQueryResultVenue {
+	return &InlineQueryResultVenue{Type: "venue", Title: title, Address: address, Latitude: latitude,
+		Longitude: longitude}
+}
+
+// NewInlineQueryResultContact creates a result that sends a contact.
+func NewInlineQueryResultContact(phoneNumber, firstName string) *InlineQueryResultContact {
+	return &InlineQueryResultContact{Type: "contact", PhoneNumber: phoneNumber, FirstName: firstName}
+}
+
+// NewInlineQueryResultGame creates a result that sends the game with gameShortName.
+func NewInlineQueryResultGame(gameShortName string) *InlineQueryResultGame {
+	return &InlineQueryResultGame{Type: "game", GameShortName: gameShortName}
+}
+
+// NewInlineQueryResultSticker creates a result of the sticker with stickerFileId.
+func NewInlineQueryResultSticker(stickerFileId string) *InlineQueryResultSticker {
+	return &InlineQueryResultSticker{Type: "sticker", StickerFileId: stickerFileId}
+}
+
+// NewInlineQueryResultCachedPhoto creates a result of the photo with photoFileId.
+func NewInlineQueryResultCachedPhoto(photoFileId string) *InlineQueryResultCachedPhoto {
+	return &InlineQueryResultCachedPhoto{Type: "photo", PhotoFileId: photoFileId}
+}
+
+// NewInlineQueryResultCachedGif creates a result of the animated GIF with gifFileId.
+func NewInlineQueryResultCachedGif(gifFileId string) *InlineQueryResultCachedGif {
+	return &InlineQueryResultCachedGif{Type: "gif", GifFileId: gifFileId}
+}
+
+// NewInlineQueryResultCachedMpeg4Gif creates a result of the soundless MPEG-4 animation with mpeg4FileId.
+func NewInlineQueryResultCachedMpeg4Gif(mpeg4FileId string) *InlineQueryResultCachedMpeg4Gif {
+	return &InlineQueryResultCachedMpeg4Gif{Type: "mpeg4_gif", Mpeg4FileId: mpeg4FileId}
+}
+
+// NewInlineQueryResultCachedVideo creates a result of the video with videoFileId.
+func NewInlineQueryResultCachedVideo(title, videoFileId string) *InlineQueryResultCachedVideo {
+	return &InlineQueryResultCachedVideo{Type: "video", Title: title, VideoFileId: videoFileId}
+}
+
+// NewInlineQueryResultCachedAudio creates a result of the MP3 file with audioFileId.
+func NewInlineQueryResultCachedAudio(audioFileId string) *InlineQueryResultCachedAudio {
+	return &InlineQueryResultCachedAudio{Type: "audio", AudioFileId: audioFileId}
+}
+
+// NewInlineQueryResultCachedVoice creates a result of the voice recording with voiceFileId.
+func NewInlineQueryResultCachedVoice(title, voiceFileId string) *InlineQueryResultCachedVoice {
+	return &InlineQueryResultCachedVoice{Type: "voice", Title: title, VoiceFileId: voiceFileId}
+}
+
+// NewInlineQueryResultCachedDocument creates a result of the file with documentFileId.
+func NewInlineQueryResultCachedDocument(title, documentFileId string) *InlineQueryResultCachedDocument {
+	return &InlineQueryResultCachedDocument{Type: "document", Title: title, DocumentFileId: documentFileId}
+}
+
+// offset returns the offset of the query as a number of results, or 0 for the first page.
+func (i InlineQuery) offset() int {
+	offset, err := strconv.Atoi(i.Offset)
+	if err != nil || offset < 0 {
+		return 0
+	}
+	return offset
+}
+
+// AnswerPaged answers the query with the page of results that starts at the offset of the query, and sets
+// NextOffset of data so telegram asks for the next page when the user scrolls. Results without an Id get an Id
+// derived from their content, which is stable between pages and queries; results is not changed. A page has at
+// most MaxInlineQueryResults results. InlineQueryId, Results and NextOffset of data are set automatically.
+// Use InlineResultTracker.AnswerPaged to track the answered results.
+func (i InlineQuery) AnswerPaged(b Bot, results []QueryAnswer, data AnswerInlineQueryData) (Response, error) {
+	data, err := i.pagedAnswer(results, data)
+	if err != nil {
+		return nil, err
+	}
+	return i.Answer(b, data)
+}
+
+// pagedAnswer returns data with the page of results of the query, as AnswerPaged sends it.
+func (i InlineQuery) pagedAnswer(results []QueryAnswer, data AnswerInlineQueryData) (AnswerInlineQueryData, error) {
+	offset := min(i.offset(), len(results))
+	end := min(offset+MaxInlineQueryResults, len(results))
+	page, err := withIds(results[offset:end])
+	if err != nil {
+		return data, err
+	}
+	data.Results, data.NextOffset = page, ""
+	if end < len(results) {
+		data.NextOffset = strconv.Itoa(end)
+	}
+	return data, nil
+}
+
+// AnswerPages is like AnswerPaged, but results are loaded a page at a time: page is called with the offset
+// of the query and the maximum number of results (MaxInlineQueryResults), and returns the results of the page.
+// When page returns fewer results than limit, it is considered the last page.
+func (i InlineQuery) AnswerPages(b Bot, page func(offset, limit int) ([]QueryAnswer, error),
+	data AnswerInlineQueryData) (Response, error) {
+	data, err := i.pagesAnswer(page, data)
+	if err != nil {
+		return nil, err
+	}
+	return i.Answer(b, data)
+}
+
+// pagesAnswer returns data with the page of results loaded by page, as AnswerPages sends it.
+func (i InlineQuery) pagesAnswer(page func(offset, limit int) ([]QueryAnswer, error),
+	data AnswerInlineQueryData) (AnswerInlineQueryData, error) {
+	offset := i.offset()
+	results, err := page(offset, MaxInlineQueryResults)
+	if err != nil {
+		return data, err
+	}
+	if len(results) > MaxInlineQueryResults {
+		return data, errors.New("page of inline query results must have at most 50 results")
+	}
+	if data.Results, err = withIds(results); err != nil {
+		return data, err
+	}
+	data.NextOffset = ""
+	if len(results) == MaxInlineQueryResults {
+		data.NextOffset = strconv.Itoa(offset + len(results))
+	}
+	return data, nil
+}
+
+// withIds returns a copy of results in which results without an Id have an Id derived from their type and
+// content, so the same result has the same Id on every page and in every answer. results is not changed.
+// Equal results in results get a "-<n>" suffix, since ids must be unique in an answer.
+func withIds(results []QueryAnswer) ([]QueryAnswer, error) {
+	page := make([]QueryAnswer, len(results))
+	seen := map[string]int{}
+	for j, result := range results {
+		v := reflect.ValueOf(result)
+		isPtr := v.Kind() == reflect.Ptr
+		if isPtr {
+			v = v.Elem()
+		}
+		if v.FieldByName("Id").Kind() != reflect.String {
+			return nil, errors.New("inline query result " + v.Type().Name() + " has no Id")
+		}
+		page[j] = result
+		if v.FieldByName("Id").String() != "" {
+			continue
+		}
+		c := reflect.New(v.Type())
+		c.Elem().Set(v)
+		content, err := json.Marshal(c.Interface())
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(append([]byte(v.Type().Name()+"|"), content...))
+		id := hex.EncodeToString(sum[:16])
+		if seen[id]++; seen[id] > 1 {
+			id += "-" + strconv.Itoa(seen[id])
+		}
+		c.Elem().FieldByName("Id").SetString(id)
+		if isPtr {
+			page[j] = c.Interface().(QueryAnswer)
+		} else {
+			page[j] = c.Elem().Interface().(QueryAnswer)
+		}
+	}
+	return page, nil
+}
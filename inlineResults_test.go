@@ -0,0 +1,124 @@
+package gogram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestInlineQuery_AnswerPaged(t *testing.T) {
+	var answer struct {
+		Results []struct {
+			Type string `json:"type"`
+			Id   string `json:"id"`
+		} `json:"results"`
+		NextOffset string `json:"next_offset"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		answer.Results, answer.NextOffset = nil, ""
+		if err := json.NewDecoder(r.Body).Decode(&answer); err != nil {
+			t.Error(err)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	var results []QueryAnswer
+	for i := 0; i < 120; i++ {
+		results = append(results, NewInlineQueryResultArticle("a", InputTextMessageContent{MessageText: "t" +
+			strconv.Itoa(i%60)}))
+	}
+	if _, err := (InlineQuery{Id: "1", Offset: "50"}).AnswerPaged(b, results, AnswerInlineQueryData{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(answer.Results) != 50 || answer.Results[0].Type != "article" || answer.NextOffset != "100" {
+		t.Errorf("unexpected answer: %+v", answer)
+	}
+	ids := map[string]bool{}
+	for _, r := range answer.Results {
+		ids[r.Id] = true
+	}
+	if len(ids) != 50 {
+		t.Errorf("ids are not unique: %+v", answer.Results)
+	}
+	if results[50].(*InlineQueryResultArticle).Id != "" {
+		t.Error("results are changed")
+	}
+	// ids depend on the content of results, not on their position
+	first := answer.Results[10].Id
+	if _, err := (InlineQuery{Id: "1"}).AnswerPaged(b, results, AnswerInlineQueryData{}); err != nil {
+		t.Fatal(err)
+	}
+	if answer.Results[0].Id != first {
+		t.Errorf("ids of equal results differ: %s and %s", answer.Results[0].Id, first)
+	}
+	if _, err := (InlineQuery{Id: "1", Offset: "100"}).AnswerPaged(b, results, AnswerInlineQueryData{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(answer.Results) != 20 || answer.NextOffset != "" {
+		t.Errorf("unexpected last page: %d results, next offset %q", len(answer.Results), answer.NextOffset)
+	}
+
+	page := func(offset, limit int) ([]QueryAnswer, error) {
+		var results []QueryAnswer
+		for i := offset; i < offset+limit; i++ {
+			results = append(results, InlineQueryResultGame{Type: "game", Id: "g" + strconv.Itoa(i),
+				GameShortName: "g"})
+		}
+		return results, nil
+	}
+	if _, err := (InlineQuery{Id: "1"}).AnswerPages(b, page, AnswerInlineQueryData{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(answer.Results) != 50 || answer.Results[1].Id != "g1" || answer.NextOffset != "50" {
+		t.Errorf("unexpected answer: %+v", answer)
+	}
+}
+
+func TestInlineQueryResultCached_Check(t *testing.T) {
+	video := NewInlineQueryResultCachedVideo("", "file")
+	video.Id = "1"
+	err := video.checkQueryAnswer()
+	if v, ok := err.(*ValidationError); !ok || len(v.Errors) != 1 || v.Errors[0].JSONField != "title" {
+		t.Errorf("expected error for title, got %v", err)
+	}
+	gif := NewInlineQueryResultCachedGif("file")
+	gif.Id, gif.Caption, gif.ParseMode = "1", "caption", "HTML"
+	if err := gif.checkQueryAnswer(); err != nil {
+		t.Fatal(err)
+	}
+	j, err := structJsonParser(gif)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(j) != `{"caption":"caption","gif_file_id":"file","id":"1","parse_mode":"HTML","type":"gif"}` {
+		t.Errorf("unexpected json: %s", j)
+	}
+}
+
+func TestAnswerInlineQueryData_CacheTime(t *testing.T) {
+	var contentType string
+	fields := map[string]string{}
+	server := stubServer(t, &contentType, fields)
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	article := NewInlineQueryResultArticle("a", InputTextMessageContent{MessageText: "t"})
+	article.Id = "1"
+	data := AnswerInlineQueryData{InlineQueryId: "q", Results: []QueryAnswer{article}}
+	if _, err := data.Send(b); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["cache_time"]; ok {
+		t.Errorf("unset cache_time is sent: %v", fields)
+	}
+	zero := 0
+	data.CacheTime = &zero
+	if _, err := data.Send(b); err != nil {
+		t.Fatal(err)
+	}
+	if fields["cache_time"] != "0" {
+		t.Errorf("cache_time 0 is not sent: %v", fields)
+	}
+}
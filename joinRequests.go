@@ -0,0 +1,196 @@
+package gogram
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Approve approves the join request.
+func (r ChatJoinRequest) Approve(b Bot) (Response, error) {
+	return ApproveChatJoinRequestData{ChatId: r.Chat.Id, UserId: r.From.Id}.Send(b)
+}
+
+// Decline declines the join request.
+func (r ChatJoinRequest) Decline(b Bot) (Response, error) {
+	return DeclineChatJoinRequestData{ChatId: r.Chat.Id, UserId: r.From.Id}.Send(b)
+}
+
+// JoinRequestHandler answers chat join requests, optionally after verifying the user.
+//
+//	captcha := &gogram.JoinCaptcha{}
+//	joins := gogram.JoinRequestHandler{Verify: captcha.Verify}
+//	switch update.TypeIndicator() {
+//	case gogram.UpdateTypeChatJoinRequest:
+//		err = joins.Handle(bot, update.ChatJoinRequest)
+//	case gogram.UpdateTypeCallbackQuery:
+//		handled, err = captcha.Handle(bot, update.CallbackQuery)
+//	}
+type JoinRequestHandler struct {
+	// Verify starts the verification of r, e.g. by sending a question to the user in a private chat, and
+	// approves or declines r when the verification ends. If it is nil, every request is approved.
+	Verify func(b Bot, r ChatJoinRequest) error
+}
+
+// Handle approves r, or starts its verification.
+func (j JoinRequestHandler) Handle(b Bot, r ChatJoinRequest) error {
+	if j.Verify != nil {
+		return j.Verify(b, r)
+	}
+	_, err := r.Approve(b)
+	return err
+}
+
+// DefaultJoinCaptchaTimeout is how long JoinCaptcha waits for an answer if Timeout is not set.
+const DefaultJoinCaptchaTimeout = 5 * time.Minute
+
+// JoinCaptcha verifies users who request to join a chat by asking them to press one of Options in a private
+// chat. The request is approved if the right option is pressed, and declined if a wrong option is pressed or
+// no option is pressed within Timeout. Pass its Verify to JoinRequestHandler, and every CallbackQuery to Handle.
+type JoinCaptcha struct {
+	// Options are the buttons of the question. Default is four fruit emojis.
+	Options []string
+	// Text is the question, in which %c is replaced by the title of the chat and %o by the option to press.
+	// Default is "To join %c, press %o".
+	Text string
+	// Timeout is how long the user has to answer. Default is DefaultJoinCaptchaTimeout.
+	Timeout time.Duration
+	mu      sync.Mutex
+	pending map[string]joinChallenge
+	tokens  int
+}
+
+type joinChallenge struct {
+	request ChatJoinRequest
+	answer  int
+	timer   *time.Timer
+	// token identifies the challenge, so the timer of a replaced challenge doesn't decline the new one.
+	token int
+}
+
+func joinChallengeKey(chatId, userId int) string {
+	return strconv.Itoa(chatId) + "|" + strconv.Itoa(userId)
+}
+
+// Verify sends the question of r to the user.
+func (j *JoinCaptcha) Verify(b Bot, r ChatJoinRequest) error {
+	options, text := j.Options, j.Text
+	if len(options) == 0 {
+		options = []string{"🍎", "🍌", "🍇", "🍒"}
+	}
+	if text == "" {
+		text = "To join %c, press %o"
+	}
+	answer := rand.Intn(len(options))
+	var buttons []InlineButton
+	for i, option := range options {
+		buttons = append(buttons, InlineButton{Text: option,
+			CallbackData: "joincaptcha|" + strconv.Itoa(r.Chat.Id) + "|" + strconv.Itoa(i)})
+	}
+	k := InlineKeyboard{}
+	if err := k.AddGrid(len(options), buttons...); err != nil {
+		return err
+	}
+	t := TextData{ChatId: r.From.Id, Text: strings.NewReplacer("%c", r.Chat.Title, "%o", options[answer]).Replace(text)}
+	t.ReplyMarkup = k
+	if _, err := t.Send(b); err != nil {
+		return err
+	}
+	j.put(b, joinChallengeKey(r.Chat.Id, r.From.Id), r, answer, true)
+	return nil
+}
+
+// put adds a challenge of r with key, which declines r when the timeout expires. A pending challenge with key
+// is replaced if replace is true, and kept otherwise.
+func (j *JoinCaptcha) put(b Bot, key string, r ChatJoinRequest, answer int, replace bool) {
+	timeout := j.Timeout
+	if timeout == 0 {
+		timeout = DefaultJoinCaptchaTimeout
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.pending == nil {
+		j.pending = map[string]joinChallenge{}
+	}
+	if old, ok := j.pending[key]; ok {
+		if !replace {
+			return
+		}
+		old.timer.Stop()
+	}
+	j.tokens++
+	token := j.tokens
+	j.pending[key] = joinChallenge{request: r, answer: answer, token: token, timer: time.AfterFunc(timeout, func() {
+		if j.take(key, token) {
+			_, _ = r.Decline(b)
+		}
+	})}
+}
+
+// claim removes the pending challenge with key and returns it, so only one answer or timeout handles it.
+func (j *JoinCaptcha) claim(key string) (joinChallenge, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	c, ok := j.pending[key]
+	if ok {
+		delete(j.pending, key)
+		c.timer.Stop()
+	}
+	return c, ok
+}
+
+// take removes the pending challenge with key if it has token, and reports whether it is removed.
+func (j *JoinCaptcha) take(key string, token int) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	c, ok := j.pending[key]
+	if !ok || c.token != token {
+		return false
+	}
+	delete(j.pending, key)
+	c.timer.Stop()
+	return true
+}
+
+// Handle handles q if it is an answer to a question of j, and returns false otherwise. The question is replaced
+// with the result and q is always answered. If the request can't be approved or declined, the question stays
+// so the user can answer again.
+func (j *JoinCaptcha) Handle(b Bot, q CallbackQuery) (bool, error) {
+	parts := strings.Split(q.Data, "|")
+	if len(parts) != 3 || parts[0] != "joincaptcha" {
+		return false, nil
+	}
+	chatId, err := strconv.Atoi(parts[1])
+	option, optionErr := strconv.Atoi(parts[2])
+	if err != nil || optionErr != nil {
+		_, _ = q.Answer(b, AnswerCallbackQueryData{})
+		return true, errors.New("invalid callback data " + q.Data)
+	}
+	key := joinChallengeKey(chatId, q.From.Id)
+	c, ok := j.claim(key)
+	if !ok {
+		_, err = q.Answer(b, AnswerCallbackQueryData{Text: "This question has expired"})
+		return true, err
+	}
+	text := "Your request to join " + c.request.Chat.Title + " is approved"
+	if option == c.answer {
+		_, err = c.request.Approve(b)
+	} else {
+		text = "Wrong answer, your request to join " + c.request.Chat.Title + " is declined"
+		_, err = c.request.Decline(b)
+	}
+	if err != nil {
+		// the user can answer again, unless the request is verified again meanwhile
+		j.put(b, key, c.request, c.answer, false)
+		_, _ = q.Answer(b, AnswerCallbackQueryData{Text: "Something went wrong, please try again"})
+		return true, err
+	}
+	_, err = q.EditText(b, EditMessageTextData{Text: text})
+	if _, answerErr := q.Answer(b, AnswerCallbackQueryData{}); err == nil {
+		err = answerErr
+	}
+	return true, err
+}
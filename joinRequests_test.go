@@ -0,0 +1,155 @@
+package gogram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCreateChatInviteLinkData_Check(t *testing.T) {
+	c := CreateChatInviteLinkData{ChatId: 1, MemberLimit: 10, CreatesJoinRequest: true}
+	if err, ok := c.Check().(*ValidationError); !ok || err.Errors[0].JSONField != "member_limit" {
+		t.Errorf("member limit with join requests is valid: %v", err)
+	}
+	c.MemberLimit = 0
+	if err := c.Check(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJoinCaptcha(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	failApprove := false
+	var keyboard struct {
+		InlineKeyboard [][]InlineButton `json:"inline_keyboard"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]json.RawMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, method)
+		if method == "sendMessage" {
+			if string(body["chat_id"]) != "5" || !strings.Contains(string(body["text"]), "Club") {
+				t.Errorf("unexpected question %s %s", body["chat_id"], body["text"])
+			}
+			_ = json.Unmarshal(body["reply_markup"], &keyboard)
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":3}}`))
+			return
+		}
+		if method == "approveChatJoinRequest" && failApprove {
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	captcha := &JoinCaptcha{Options: []string{"a", "b"}, Timeout: 20 * time.Millisecond}
+	joins := JoinRequestHandler{Verify: captcha.Verify}
+	request := ChatJoinRequest{Chat: Chat{ReplyAble: ReplyAble{Id: -100}, Title: "Club"},
+		From: User{ReplyAble: ReplyAble{Id: 5}}, Date: 1}
+	if err := joins.Handle(b, request); err != nil {
+		t.Fatal(err)
+	}
+	answer := captcha.pending[joinChallengeKey(-100, 5)].answer
+	q := CallbackQuery{Id: "q", From: request.From, Data: keyboard.InlineKeyboard[0][answer].CallbackData,
+		Message: Message{MessageId: 3, Chat: Chat{ReplyAble: ReplyAble{Id: 5}}}}
+	// a request that can't be approved is still pending, and the query is answered
+	mu.Lock()
+	failApprove = true
+	mu.Unlock()
+	if handled, err := captcha.Handle(b, q); !handled || err == nil {
+		t.Fatal(handled, err)
+	}
+	mu.Lock()
+	failApprove = false
+	mu.Unlock()
+	if handled, err := captcha.Handle(b, q); !handled || err != nil {
+		t.Fatal(handled, err)
+	}
+	if err := joins.Handle(b, request); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(calls, ",") != "sendMessage,approveChatJoinRequest,answerCallbackQuery,approveChatJoinRequest,"+
+		"editMessageText,answerCallbackQuery,sendMessage,declineChatJoinRequest" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+}
+
+func TestJoinCaptcha_ReplacedChallenge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":3}}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	captcha := &JoinCaptcha{Timeout: time.Hour}
+	request := ChatJoinRequest{Chat: Chat{ReplyAble: ReplyAble{Id: -100}, Title: "Club"},
+		From: User{ReplyAble: ReplyAble{Id: 5}}}
+	key := joinChallengeKey(-100, 5)
+	if err := captcha.Verify(b, request); err != nil {
+		t.Fatal(err)
+	}
+	old := captcha.pending[key].token
+	if err := captcha.Verify(b, request); err != nil {
+		t.Fatal(err)
+	}
+	// a timer of the old challenge that fired before it was stopped must not take the new one
+	if captcha.take(key, old) {
+		t.Error("new challenge is taken with the token of the old one")
+	}
+	if !captcha.take(key, captcha.pending[key].token) {
+		t.Error("new challenge is not taken")
+	}
+}
+
+func TestJoinCaptcha_TimeoutWhileAnswering(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	approving := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		mu.Lock()
+		calls = append(calls, method)
+		mu.Unlock()
+		if method == "approveChatJoinRequest" {
+			// the timeout expires while the request is being approved
+			<-approving
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":3}}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	captcha := &JoinCaptcha{Timeout: 20 * time.Millisecond}
+	request := ChatJoinRequest{Chat: Chat{ReplyAble: ReplyAble{Id: -100}, Title: "Club"},
+		From: User{ReplyAble: ReplyAble{Id: 5}}}
+	if err := captcha.Verify(b, request); err != nil {
+		t.Fatal(err)
+	}
+	answer := captcha.pending[joinChallengeKey(-100, 5)].answer
+	q := CallbackQuery{Id: "q", From: request.From, Data: "joincaptcha|-100|" + strconv.Itoa(answer),
+		Message: Message{MessageId: 3, Chat: Chat{ReplyAble: ReplyAble{Id: 5}}}}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(approving)
+	}()
+	if handled, err := captcha.Handle(b, q); !handled || err != nil {
+		t.Fatal(handled, err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(calls, ",") != "sendMessage,approveChatJoinRequest,editMessageText,answerCallbackQuery" {
+		t.Errorf("unexpected calls %v", calls)
+	}
+}
@@ -0,0 +1,170 @@
+package gogram
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Layout limits of inline keyboards.
+const (
+	MaxInlineButtonsPerRow = 8
+	MaxInlineButtons       = 100
+)
+
+// AddRow adds a row of buttons to the bottom of the keyboard.
+func (i *InlineKeyboard) AddRow(a ...InlineButton) error {
+	return i.InsertRow(len(i.Buttons), a...)
+}
+
+// InsertRow inserts a row of buttons before row index, e.g. a navigation row on top of the keyboard
+// with index 0. index must be between 0 and the number of rows.
+func (i *InlineKeyboard) InsertRow(index int, a ...InlineButton) error {
+	if index < 0 || index > len(i.Buttons) {
+		return errors.New("row index " + strconv.Itoa(index) + " is out of range")
+	}
+	if len(a) == 0 {
+		return errors.New("row of InlineKeyboard is empty")
+	}
+	buttons := make([][]InlineButton, 0, len(i.Buttons)+1)
+	buttons = append(buttons, i.Buttons[:index]...)
+	buttons = append(buttons, a)
+	buttons = append(buttons, i.Buttons[index:]...)
+	if err := (InlineKeyboard{Buttons: buttons}).check(); err != nil {
+		return err
+	}
+	i.Buttons = buttons
+	return nil
+}
+
+// AddGrid adds buttons to the bottom of the keyboard in rows of columns buttons. The last row
+// has fewer buttons if the number of buttons is not a multiple of columns.
+func (i *InlineKeyboard) AddGrid(columns int, a ...InlineButton) error {
+	if columns < 1 || columns > MaxInlineButtonsPerRow {
+		return errors.New("columns of InlineKeyboard must be between 1 and 8")
+	}
+	k := InlineKeyboard{Buttons: i.Buttons}
+	for _, row := range grid(len(a), columns) {
+		if err := k.AddRow(a[row[0]:row[1]]...); err != nil {
+			return err
+		}
+	}
+	i.Buttons = k.Buttons
+	return nil
+}
+
+// checkLayout checks the number of buttons in rows and in the whole keyboard.
+func (i InlineKeyboard) checkLayout() error {
+	total := 0
+	for _, row := range i.Buttons {
+		if len(row) > MaxInlineButtonsPerRow {
+			return errors.New("a row of InlineKeyboard can have at most 8 buttons")
+		}
+		total += len(row)
+	}
+	if total > MaxInlineButtons {
+		return errors.New("InlineKeyboard can have at most 100 buttons")
+	}
+	return nil
+}
+
+// AddRow adds a row of buttons to the bottom of the keyboard.
+func (r *ReplyKeyboard) AddRow(a ...ReplyButton) error {
+	return r.InsertRow(len(r.Keyboard), a...)
+}
+
+// InsertRow inserts a row of buttons before row index. index must be between 0 and the number of rows.
+func (r *ReplyKeyboard) InsertRow(index int, a ...ReplyButton) error {
+	if index < 0 || index > len(r.Keyboard) {
+		return errors.New("row index " + strconv.Itoa(index) + " is out of range")
+	}
+	if len(a) == 0 {
+		return errors.New("row of ReplyKeyboard is empty")
+	}
+	for _, button := range a {
+		if err := button.check(); err != nil {
+			return err
+		}
+	}
+	buttons := make([][]ReplyButton, 0, len(r.Keyboard)+1)
+	buttons = append(buttons, r.Keyboard[:index]...)
+	buttons = append(buttons, a)
+	r.Keyboard = append(buttons, r.Keyboard[index:]...)
+	return nil
+}
+
+// AddGrid adds buttons to the bottom of the keyboard in rows of columns buttons.
+func (r *ReplyKeyboard) AddGrid(columns int, a ...ReplyButton) error {
+	if columns < 1 {
+		return errors.New("columns of ReplyKeyboard must be at least 1")
+	}
+	k := ReplyKeyboard{Keyboard: r.Keyboard}
+	for _, row := range grid(len(a), columns) {
+		if err := k.AddRow(a[row[0]:row[1]]...); err != nil {
+			return err
+		}
+	}
+	r.Keyboard = k.Keyboard
+	return nil
+}
+
+// grid returns start and end indexes of rows of n buttons laid out in columns.
+func grid(n, columns int) [][2]int {
+	var rows [][2]int
+	for start := 0; start < n; start += columns {
+		rows = append(rows, [2]int{start, min(start+columns, n)})
+	}
+	return rows
+}
+
+// inlineKeyboard returns the inline keyboard of k, or a new one if k doesn't have one.
+func (k *Keyboard) inlineKeyboard() InlineKeyboard {
+	i, _ := (k.ReplyMarkup).(InlineKeyboard)
+	return i
+}
+
+// SetInlineKeyboardRows adds rows of buttons to the inline keyboard of the message.
+func (k *Keyboard) SetInlineKeyboardRows(rows ...[]InlineButton) error {
+	i := k.inlineKeyboard()
+	for _, row := range rows {
+		if err := i.AddRow(row...); err != nil {
+			return err
+		}
+	}
+	k.ReplyMarkup = i
+	return nil
+}
+
+// SetInlineKeyboardGrid adds buttons to the inline keyboard of the message in rows of columns buttons.
+func (k *Keyboard) SetInlineKeyboardGrid(columns int, a ...InlineButton) error {
+	i := k.inlineKeyboard()
+	if err := i.AddGrid(columns, a...); err != nil {
+		return err
+	}
+	k.ReplyMarkup = i
+	return nil
+}
+
+// SetInlineKeyboardMarkup replaces the keyboard of the message with i, e.g. a keyboard built with AddRow and
+// AddGrid.
+func (k *Keyboard) SetInlineKeyboardMarkup(i InlineKeyboard) error {
+	if err := i.check(); err != nil {
+		return err
+	}
+	k.ReplyMarkup = i
+	return nil
+}
+
+// SetReplyKeyboardGrid adds buttons to the reply keyboard of the message in rows of columns buttons.
+// optionalParams.Horizontal is ignored.
+func (k *Keyboard) SetReplyKeyboardGrid(optionalParams ReplyKeyboardOP, columns int, a ...ReplyButton) error {
+	r, _ := (k.ReplyMarkup).(ReplyKeyboard)
+	if err := r.AddGrid(columns, a...); err != nil {
+		return err
+	}
+	r.OneTimeKeyboard = optionalParams.OneTimeKeyboard
+	r.Selective = optionalParams.Selective
+	r.InputFieldPlaceholder = optionalParams.InputFieldPlaceholder
+	r.ResizeKeyboard = optionalParams.ResizeKeyboard
+	k.ReplyMarkup = r
+	return nil
+}
@@ -0,0 +1,54 @@
+package gogram
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func buttons(n int) []InlineButton {
+	a := make([]InlineButton, n)
+	for i := range a {
+		a[i] = InlineButton{Text: strconv.Itoa(i), CallbackData: strconv.Itoa(i)}
+	}
+	return a
+}
+
+func TestInlineKeyboard_AddGrid(t *testing.T) {
+	k := InlineKeyboard{}
+	a := buttons(7)
+	if err := k.AddGrid(3, a...); err != nil {
+		t.Fatal(err)
+	}
+	nav := InlineButton{Text: "«", CallbackData: "prev"}
+	if err := k.InsertRow(0, nav); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]InlineButton{{nav}, a[0:3], a[3:6], a[6:7]}
+	if !reflect.DeepEqual(k.Buttons, want) {
+		t.Errorf("got %v, want %v", k.Buttons, want)
+	}
+	if err := k.AddRow(buttons(9)...); err == nil {
+		t.Error("a row of 9 buttons is accepted")
+	}
+	if err := k.AddGrid(8, buttons(93)...); err == nil {
+		t.Error("more than 100 buttons are accepted")
+	}
+	if len(k.Buttons) != 4 {
+		t.Errorf("keyboard is changed by a failed AddGrid: %v", k.Buttons)
+	}
+}
+
+func TestKeyboard_SetInlineKeyboardGrid(t *testing.T) {
+	d := TextData{ChatId: 1, Text: "t"}
+	if err := d.SetInlineKeyboardGrid(2, buttons(3)...); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetInlineKeyboardRows(buttons(1)); err != nil {
+		t.Fatal(err)
+	}
+	k, ok := d.ReplyMarkup.(InlineKeyboard)
+	if !ok || len(k.Buttons) != 3 || len(k.Buttons[0]) != 2 || len(k.Buttons[2]) != 1 {
+		t.Errorf("unexpected keyboard %v", d.ReplyMarkup)
+	}
+}
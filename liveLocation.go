@@ -0,0 +1,113 @@
+package gogram
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultLiveLocationInterval is how often LiveLocationSession edits the location if interval is 0.
+const DefaultLiveLocationInterval = 5 * time.Second
+
+// LiveLocationSession sends a live location and keeps it up to date with the locations it receives, until
+// it is stopped or its LivePeriod expires. Use StartLiveLocation to create one.
+type LiveLocationSession struct {
+	// Message is the live location message.
+	Message Message
+	bot     Bot
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+	err     error
+}
+
+// StartLiveLocation sends data, which must have a LivePeriod, and edits the message with the latest location
+// received from updates at most once per interval, so telegram doesn't limit the bot. The session stops when
+// Stop is called, when updates is closed, or when the live period expires.
+func StartLiveLocation(b Bot, data LocationData, updates <-chan Location,
+	interval time.Duration) (*LiveLocationSession, error) {
+	if data.LivePeriod == 0 {
+		return nil, errors.New("LivePeriod of a live location must be set")
+	}
+	if interval < 0 {
+		return nil, errors.New("interval of a live location must not be negative")
+	} else if interval == 0 {
+		interval = DefaultLiveLocationInterval
+	}
+	res, err := data.Send(b)
+	if err != nil {
+		return nil, err
+	}
+	s := &LiveLocationSession{Message: *res.getResult().(*Message), bot: b, stop: make(chan struct{}),
+		done: make(chan struct{})}
+	go s.run(updates, interval, time.Now().Add(time.Duration(data.LivePeriod)*time.Second))
+	return s, nil
+}
+
+func (s *LiveLocationSession) run(updates <-chan Location, interval time.Duration, expires time.Time) {
+	defer close(s.done)
+	expired := time.NewTimer(time.Until(expires))
+	defer expired.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var latest *Location
+	for {
+		select {
+		case l, ok := <-updates:
+			if !ok {
+				if s.err = s.push(latest); s.err == nil {
+					s.err = s.stopLocation()
+				}
+				return
+			}
+			latest = &l
+		case <-ticker.C:
+			if s.err = s.push(latest); s.err != nil {
+				return
+			}
+			latest = nil
+		case <-s.stop:
+			if s.err = s.push(latest); s.err == nil {
+				s.err = s.stopLocation()
+			}
+			return
+		case <-expired.C:
+			return
+		}
+	}
+}
+
+// push edits the message with l, if it is not nil.
+func (s *LiveLocationSession) push(l *Location) error {
+	if l == nil {
+		return nil
+	}
+	_, err := EditMessageLiveLocationData{ChatId: s.Message.Chat.Id, MessageId: s.Message.MessageId,
+		Latitude: l.Latitude, Longitude: l.Longitude, HorizontalAccuracy: l.HorizontalAccuracy, Heading: l.Heading,
+		ProximityAlertRadius: l.ProximityAlertRadius}.Send(s.bot)
+	return err
+}
+
+func (s *LiveLocationSession) stopLocation() error {
+	_, err := StopMessageLiveLocationData{ChatId: s.Message.Chat.Id, MessageId: s.Message.MessageId}.Send(s.bot)
+	return err
+}
+
+// Stop stops the live location and waits for the session to end. A location received since the last edit is
+// sent before the live location is stopped. It returns the error that ended the session.
+func (s *LiveLocationSession) Stop() error {
+	s.once.Do(func() { close(s.stop) })
+	<-s.done
+	return s.err
+}
+
+// Done is closed when the session ends.
+func (s *LiveLocationSession) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that ended the session, after Done is closed.
+func (s *LiveLocationSession) Err() error {
+	<-s.done
+	return s.err
+}
@@ -0,0 +1,72 @@
+package gogram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLocationData_CheckLive(t *testing.T) {
+	l := LocationData{ChatId: 1, Location: Location{Latitude: 52.5, Longitude: 13.4, Heading: 90}}
+	if err, ok := l.Check().(*ValidationError); !ok || err.Errors[0].JSONField != "heading" {
+		t.Errorf("heading of a static location is valid: %v", err)
+	}
+	l.LivePeriod = 60
+	if err := l.Check(); err != nil {
+		t.Error(err)
+	}
+	l.LivePeriod, l.Latitude = 30, 91
+	if err, ok := l.Check().(*ValidationError); !ok || len(err.Errors) != 2 {
+		t.Errorf("expected latitude and live period errors, got %v", err)
+	}
+}
+
+func TestLiveLocationSession(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		mu.Lock()
+		calls = append(calls, method)
+		mu.Unlock()
+		if method != "sendLocation" && (body["chat_id"] != 5.0 || body["message_id"] != 9.0) {
+			t.Errorf("%s is sent to the wrong message: %v", method, body)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":9,"chat":{"id":5}}}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	data := LocationData{ChatId: 5, Location: Location{Latitude: 1, Longitude: 1, LivePeriod: 60}}
+	if _, err := StartLiveLocation(b, data, nil, -time.Second); err == nil {
+		t.Error("negative interval is accepted")
+	}
+	// the interval is never reached, so locations are only sent when the session ends
+	for name, end := range map[string]func(*LiveLocationSession, chan Location) error{
+		"stop":  func(s *LiveLocationSession, _ chan Location) error { return s.Stop() },
+		"close": func(s *LiveLocationSession, updates chan Location) error { close(updates); return s.Err() },
+	} {
+		mu.Lock()
+		calls = nil
+		mu.Unlock()
+		updates := make(chan Location)
+		s, err := StartLiveLocation(b, data, updates, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updates <- Location{Latitude: 2, Longitude: 2}
+		if err = end(s, updates); err != nil {
+			t.Fatal(err)
+		}
+		mu.Lock()
+		if strings.Join(calls, ",") != "sendLocation,editMessageLiveLocation,stopMessageLiveLocation" {
+			t.Errorf("%s: unexpected calls %v", name, calls)
+		}
+		mu.Unlock()
+	}
+}
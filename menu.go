@@ -0,0 +1,323 @@
+package gogram
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Menu is a message with an inline keyboard of items, which can open submenus, switch settings or run
+// actions. Build a tree of menus, call Build of the root once it is set up, send the root with Send, and pass
+// every CallbackQuery to Handle of the root; the message is edited in place as the user moves between menus.
+//
+//	settings := &gogram.Menu{Id: "settings", Title: "Settings"}
+//	settings.Items = []gogram.MenuItem{
+//		gogram.ToggleItem("Notifications", getNotifications, setNotifications),
+//		gogram.RadioItem([]string{"en", "de"}, getLanguage, setLanguage),
+//	}
+//	root := (&gogram.Menu{Id: "main", Title: "Main menu", Items: []gogram.MenuItem{
+//		gogram.SubmenuItem("⚙ Settings", settings),
+//	}}).Build()
+type Menu struct {
+	// Id identifies the menu in callback data. It must be unique in the tree and must not contain "|".
+	// This field is mandatory.
+	Id string
+	// Title is the text of the message when the menu is shown. This field is mandatory.
+	Title     string
+	ParseMode string
+	Items     []MenuItem
+	// Columns is the number of buttons in each row. Default is 1. Radio items always have a row of their own.
+	Columns int
+	// BackText is the label of the button that opens the parent menu. Default is "« Back".
+	BackText string
+	parent   *Menu
+}
+
+// MenuContext is passed to functions of menu items.
+type MenuContext struct {
+	Bot Bot
+	// Query is the callback query that is being handled. It is empty when a menu is rendered by Send.
+	Query CallbackQuery
+	// ChatId is the chat of the menu message. It is 0 for menus in inline messages.
+	ChatId int
+	// Menu is the menu that is shown.
+	Menu *Menu
+}
+
+// MenuItem is an item of a Menu. Create items with SubmenuItem, ToggleItem, RadioItem, ActionItem, UrlItem
+// and DynamicItems.
+type MenuItem struct {
+	text    string
+	submenu *Menu
+	url     string
+	toggle  func(MenuContext) bool
+	set     func(MenuContext, bool) error
+	options []string
+	radio   func(MenuContext) string
+	choose  func(MenuContext, string) error
+	action  func(MenuContext) error
+	dynamic func(MenuContext) []MenuItem
+}
+
+// SubmenuItem opens submenu, which has a button to come back.
+func SubmenuItem(text string, submenu *Menu) MenuItem {
+	return MenuItem{text: text, submenu: submenu}
+}
+
+// ToggleItem switches a setting on and off. get returns the current state and set is called with the new one.
+// If set is nil, the item only shows the state; if get is nil, the state is off.
+func ToggleItem(text string, get func(MenuContext) bool, set func(MenuContext, bool) error) MenuItem {
+	if get == nil {
+		get = func(MenuContext) bool { return false }
+	}
+	return MenuItem{text: text, toggle: get, set: set}
+}
+
+// RadioItem shows a row of options, one of which is chosen. get returns the chosen option and choose is called
+// when another option is pressed. If choose is nil, the item only shows the chosen option; if get is nil, no
+// option is chosen.
+func RadioItem(options []string, get func(MenuContext) string, choose func(MenuContext, string) error) MenuItem {
+	if get == nil {
+		get = func(MenuContext) string { return "" }
+	}
+	return MenuItem{options: options, radio: get, choose: choose}
+}
+
+// ActionItem calls action when it is pressed. The menu is not changed, so action can send or edit messages.
+func ActionItem(text string, action func(MenuContext) error) MenuItem {
+	return MenuItem{text: text, action: action}
+}
+
+// UrlItem opens url.
+func UrlItem(text, url string) MenuItem {
+	return MenuItem{text: text, url: url}
+}
+
+// DynamicItems is replaced by the items items returns every time the menu is rendered or handled,
+// e.g. a list of the user's files. The items must not change between rendering a menu and pressing its buttons.
+func DynamicItems(items func(MenuContext) []MenuItem) MenuItem {
+	return MenuItem{dynamic: items}
+}
+
+// items returns items of m, with dynamic items expanded.
+func (m *Menu) items(ctx MenuContext) []MenuItem {
+	var items []MenuItem
+	for _, item := range m.Items {
+		if item.dynamic != nil {
+			items = append(items, item.dynamic(ctx)...)
+		} else {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// callbackData returns callback data of buttons of m, in "menu|<menu id>|<operation>|<item>|<option>" format.
+func (m *Menu) callbackData(operation string, indexes ...int) string {
+	data := "menu|" + m.Id + "|" + operation
+	for _, i := range indexes {
+		data += "|" + strconv.Itoa(i)
+	}
+	return data
+}
+
+// Keyboard renders m into an inline keyboard.
+func (m *Menu) Keyboard(ctx MenuContext) (InlineKeyboard, error) {
+	return m.keyboard(ctx, m.parent)
+}
+
+// keyboard renders m into an inline keyboard, with a button that opens parent if it is not nil.
+func (m *Menu) keyboard(ctx MenuContext, parent *Menu) (InlineKeyboard, error) {
+	if m.Id == "" || strings.Contains(m.Id, "|") {
+		return InlineKeyboard{}, errors.New("Id of Menu must be set and must not contain |")
+	}
+	columns := m.Columns
+	if columns == 0 {
+		columns = 1
+	}
+	k := InlineKeyboard{}
+	var row []InlineButton
+	flush := func() error {
+		if len(row) == 0 {
+			return nil
+		}
+		err := k.AddRow(row...)
+		row = nil
+		return err
+	}
+	for i, item := range m.items(ctx) {
+		var button InlineButton
+		switch {
+		case item.submenu != nil:
+			button = InlineButton{Text: item.text, CallbackData: item.submenu.callbackData("o")}
+		case item.url != "":
+			button = InlineButton{Text: item.text, Url: item.url}
+		case item.toggle != nil:
+			mark := "⬜ "
+			if item.toggle(ctx) {
+				mark = "✅ "
+			}
+			button = InlineButton{Text: mark + item.text, CallbackData: m.callbackData("t", i)}
+		case item.options != nil:
+			if err := flush(); err != nil {
+				return InlineKeyboard{}, err
+			}
+			chosen := item.radio(ctx)
+			for j, option := range item.options {
+				mark := "○ "
+				if option == chosen {
+					mark = "● "
+				}
+				row = append(row, InlineButton{Text: mark + option, CallbackData: m.callbackData("r", i, j)})
+			}
+			if err := flush(); err != nil {
+				return InlineKeyboard{}, err
+			}
+			continue
+		case item.action != nil:
+			button = InlineButton{Text: item.text, CallbackData: m.callbackData("a", i)}
+		default:
+			return InlineKeyboard{}, errors.New("item " + strconv.Itoa(i) + " of menu " + m.Id + " is empty")
+		}
+		if len(button.CallbackData) > MaxCallbackDataLength {
+			return InlineKeyboard{}, errors.New("Id of menu " + m.Id + " is too long for callback data")
+		}
+		if row = append(row, button); len(row) == columns {
+			if err := flush(); err != nil {
+				return InlineKeyboard{}, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return InlineKeyboard{}, err
+	}
+	if parent != nil {
+		back := m.BackText
+		if back == "" {
+			back = "« Back"
+		}
+		if err := k.AddRow(InlineButton{Text: back, CallbackData: parent.callbackData("o")}); err != nil {
+			return InlineKeyboard{}, err
+		}
+	}
+	return k, nil
+}
+
+// Build sets the parents of the submenus in the tree of m, so their keyboards have a button to come back,
+// and returns m. It must be called once the tree is set up, before the menu is sent. Submenus of dynamic items
+// are linked when they are opened.
+func (m *Menu) Build() *Menu {
+	m.build(map[*Menu]bool{})
+	return m
+}
+
+func (m *Menu) build(visited map[*Menu]bool) {
+	visited[m] = true
+	for _, item := range m.Items {
+		if item.submenu != nil && !visited[item.submenu] {
+			item.submenu.parent = m
+			item.submenu.build(visited)
+		}
+	}
+}
+
+// find returns the menu with id in the tree of m and its parent, or nil. It doesn't change the tree, so
+// queries can be handled concurrently.
+func (m *Menu) find(ctx MenuContext, id string, parent *Menu, visited map[*Menu]bool) (*Menu, *Menu) {
+	if m.Id == id {
+		return m, parent
+	}
+	visited[m] = true
+	ctx.Menu = m
+	for _, item := range m.items(ctx) {
+		if item.submenu != nil && !visited[item.submenu] {
+			if found, p := item.submenu.find(ctx, id, m, visited); found != nil {
+				return found, p
+			}
+		}
+	}
+	return nil, nil
+}
+
+// Send sends m to chatId as a new message.
+func (m *Menu) Send(b Bot, chatId int) (Response, error) {
+	ctx := MenuContext{Bot: b, ChatId: chatId, Menu: m}
+	k, err := m.Keyboard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := TextData{ChatId: chatId, Text: m.Title, ParseMode: m.ParseMode}
+	t.ReplyMarkup = k
+	return t.Send(b)
+}
+
+// Handle handles q if it is sent by a button of m or its submenus, and returns false otherwise. m must be
+// the root menu. Opening a menu and changing toggles or radio items edit the message in place; q is always
+// answered.
+func (m *Menu) Handle(b Bot, q CallbackQuery) (bool, error) {
+	parts := strings.Split(q.Data, "|")
+	if len(parts) < 3 || parts[0] != "menu" {
+		return false, nil
+	}
+	ctx := MenuContext{Bot: b, Query: q, ChatId: q.Message.Chat.Id}
+	menu, parent := m.find(ctx, parts[1], nil, map[*Menu]bool{})
+	if menu == nil {
+		return false, nil
+	}
+	ctx.Menu = menu
+	var indexes []int
+	for _, p := range parts[3:] {
+		i, err := strconv.Atoi(p)
+		if err != nil {
+			return true, errors.New("invalid callback data " + q.Data)
+		}
+		indexes = append(indexes, i)
+	}
+	items := menu.items(ctx)
+	var item MenuItem
+	if len(indexes) > 0 {
+		if indexes[0] < 0 || indexes[0] >= len(items) {
+			return true, errors.New("item of callback data " + q.Data + " doesn't exist")
+		}
+		item = items[indexes[0]]
+	}
+	var err error
+	switch {
+	case parts[2] == "o":
+	case parts[2] == "t" && item.toggle != nil:
+		// read-only toggles have no set
+		if item.set != nil {
+			err = item.set(ctx, !item.toggle(ctx))
+		}
+	case parts[2] == "r" && item.options != nil && len(indexes) == 2 && indexes[1] >= 0 &&
+		indexes[1] < len(item.options):
+		if item.choose != nil && item.options[indexes[1]] != item.radio(ctx) {
+			err = item.choose(ctx, item.options[indexes[1]])
+		}
+	case parts[2] == "a" && item.action != nil:
+		if err = item.action(ctx); err == nil {
+			_, err = q.Answer(b, AnswerCallbackQueryData{})
+		}
+		return true, err
+	default:
+		return true, errors.New("invalid callback data " + q.Data)
+	}
+	if err != nil {
+		return true, err
+	}
+	k, err := menu.keyboard(ctx, parent)
+	if err != nil {
+		return true, err
+	}
+	// telegram rejects an edit that changes nothing, e.g. when a menu is opened twice
+	if q.Message.Text != menu.Title || !reflect.DeepEqual(q.Message.ReplyMarkup.Buttons, k.Buttons) {
+		res, err := q.EditText(b, EditMessageTextData{Text: menu.Title, ParseMode: menu.ParseMode,
+			InlineKeyboard: k})
+		if err != nil && (res == nil || !strings.Contains(res.getDescription(), "message is not modified")) {
+			return true, err
+		}
+	}
+	_, err = q.Answer(b, AnswerCallbackQueryData{})
+	return true, err
+}
@@ -0,0 +1,124 @@
+package gogram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testMenu(notifications *bool, language *string) *Menu {
+	settings := &Menu{Id: "settings", Title: "Settings", Columns: 2, Items: []MenuItem{
+		ToggleItem("Notifications", func(MenuContext) bool { return *notifications },
+			func(_ MenuContext, on bool) error { *notifications = on; return nil }),
+		RadioItem([]string{"en", "de"}, func(MenuContext) string { return *language },
+			func(_ MenuContext, option string) error { *language = option; return nil }),
+	}}
+	return &Menu{Id: "main", Title: "Main", Items: []MenuItem{SubmenuItem("Settings", settings),
+		UrlItem("Help", "https://a.b")}}
+}
+
+func TestMenu_Keyboard(t *testing.T) {
+	notifications, language := true, "de"
+	root := testMenu(&notifications, &language)
+	root.Build()
+	settings := root.Items[0].submenu
+	k, err := settings.Keyboard(MenuContext{Menu: settings})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]InlineButton{{{Text: "✅ Notifications", CallbackData: "menu|settings|t|0"}},
+		{{Text: "○ en", CallbackData: "menu|settings|r|1|0"}, {Text: "● de", CallbackData: "menu|settings|r|1|1"}},
+		{{Text: "« Back", CallbackData: "menu|main|o"}}}
+	if !reflect.DeepEqual(k.Buttons, want) {
+		t.Errorf("got %v, want %v", k.Buttons, want)
+	}
+}
+
+func TestMenu_Handle(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	notifications, language := true, "de"
+	root := testMenu(&notifications, &language)
+	message := Message{MessageId: 1, Chat: Chat{ReplyAble: ReplyAble{Id: 1}}}
+	for _, data := range []string{"menu|settings|o", "menu|settings|t|0", "menu|settings|r|1|0"} {
+		if ok, err := root.Handle(b, CallbackQuery{Id: "1", Data: data, Message: message}); !ok || err != nil {
+			t.Fatalf("%s is not handled: %v %v", data, ok, err)
+		}
+	}
+	if notifications || language != "en" {
+		t.Errorf("settings are not changed: %v %s", notifications, language)
+	}
+	if len(methods) != 6 || methods[0] != "editMessageText" || methods[1] != "answerCallbackQuery" {
+		t.Errorf("unexpected requests %v", methods)
+	}
+	if ok, _ := root.Handle(b, CallbackQuery{Data: "p|1"}); ok {
+		t.Error("foreign callback query is handled")
+	}
+}
+
+func TestMenu_HandleNotModified(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		methods = append(methods, method)
+		if method == "editMessageText" {
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message is not ` +
+				`modified: specified new message content and reply markup are exactly the same"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	// submenus that open each other don't make the tree infinite
+	a := &Menu{Id: "a", Title: "A"}
+	c := &Menu{Id: "c", Title: "C", Items: []MenuItem{SubmenuItem("A", a)}}
+	a.Items = []MenuItem{SubmenuItem("B", &Menu{Id: "b", Title: "B", Items: []MenuItem{SubmenuItem("C", c)}})}
+	a.Build()
+	message := Message{MessageId: 1, Chat: Chat{ReplyAble: ReplyAble{Id: 1}}}
+	if ok, err := a.Handle(b, CallbackQuery{Id: "1", Data: "menu|c|o", Message: message}); !ok || err != nil {
+		t.Fatalf("query is not handled: %v %v", ok, err)
+	}
+	if !reflect.DeepEqual(methods, []string{"editMessageText", "answerCallbackQuery"}) {
+		t.Errorf("unexpected requests %v", methods)
+	}
+	if ok, _ := a.Handle(b, CallbackQuery{Id: "1", Data: "menu|x|o", Message: message}); ok {
+		t.Error("unknown menu is handled")
+	}
+	// an unchanged menu is not edited
+	methods = nil
+	k, err := a.Keyboard(MenuContext{Menu: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	message.Text, message.ReplyMarkup = "A", k
+	if ok, err := a.Handle(b, CallbackQuery{Id: "1", Data: "menu|a|o", Message: message}); !ok || err != nil {
+		t.Fatalf("query is not handled: %v %v", ok, err)
+	}
+	if !reflect.DeepEqual(methods, []string{"answerCallbackQuery"}) {
+		t.Errorf("unexpected requests %v", methods)
+	}
+}
+
+func TestMenu_HandleReadOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	root := (&Menu{Id: "main", Title: "Main", Items: []MenuItem{ToggleItem("Notifications", nil, nil),
+		RadioItem([]string{"en", "de"}, nil, nil)}}).Build()
+	message := Message{MessageId: 1, Chat: Chat{ReplyAble: ReplyAble{Id: 1}}}
+	for _, data := range []string{"menu|main|t|0", "menu|main|r|1|1"} {
+		if ok, err := root.Handle(b, CallbackQuery{Id: "1", Data: data, Message: message}); !ok || err != nil {
+			t.Errorf("%s is not handled: %v %v", data, ok, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package gogram
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// currencyExponents holds the number of digits after the decimal point of currencies that telegram supports
+// (https://core.telegram.org/bots/payments#supported-currencies). Amounts of payments are integers in the
+// smallest units of the currency, e.g. 145 for US$ 1.45.
+var currencyExponents = map[string]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ARS": 2, "AUD": 2, "AZN": 2, "BAM": 2, "BDT": 2, "BGN": 2,
+	"BND": 2, "BOB": 2, "BRL": 2, "BYN": 2, "CAD": 2, "CHF": 2, "CLP": 0, "CNY": 2, "COP": 2, "CRC": 2,
+	"CZK": 2, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2, "ETB": 2, "EUR": 2, "GBP": 2, "GEL": 2, "GTQ": 2,
+	"HKD": 2, "HNL": 2, "HRK": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "ISK": 0, "JMD": 2, "JPY": 0,
+	"KES": 2, "KGS": 2, "KRW": 0, "KZT": 2, "LBP": 2, "LKR": 2, "MAD": 2, "MDL": 2, "MNT": 2, "MUR": 2,
+	"MVR": 2, "MXN": 2, "MYR": 2, "MZN": 2, "NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "PAB": 2,
+	"PEN": 2, "PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2, "SAR": 2,
+	"SEK": 2, "SGD": 2, "THB": 2, "TJS": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0,
+	"USD": 2, "UYU": 2, "UZS": 2, "VND": 0, "YER": 2, "ZAR": 2,
+}
+
+// CurrencyExponent returns the number of digits after the decimal point of currency, a three-letter ISO 4217
+// code, and false if telegram doesn't support it.
+func CurrencyExponent(currency string) (int, bool) {
+	exp, ok := currencyExponents[strings.ToUpper(currency)]
+	return exp, ok
+}
+
+func currencyExponent(currency string) (int, error) {
+	exp, ok := CurrencyExponent(currency)
+	if !ok {
+		return 0, errors.New("currency " + currency + " is not supported")
+	}
+	return exp, nil
+}
+
+// ToSmallestUnits converts amount, like 1.45, into the smallest units of currency, like 145 for USD,
+// rounding to the nearest unit.
+func ToSmallestUnits(amount float64, currency string) (int, error) {
+	exp, err := currencyExponent(currency)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Round(amount * math.Pow10(exp))), nil
+}
+
+// ParseAmount parses a decimal amount, like "1.45", into the smallest units of currency without rounding.
+// It returns an error if amount has more digits after the decimal point than currency.
+func ParseAmount(amount, currency string) (int, error) {
+	exp, err := currencyExponent(currency)
+	if err != nil {
+		return 0, err
+	}
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if len(fraction) > exp {
+		return 0, errors.New(currency + " amounts have at most " + strconv.Itoa(exp) + " digits after the decimal point")
+	}
+	negative := strings.HasPrefix(whole, "-")
+	whole = strings.TrimPrefix(whole, "-")
+	digits := whole + fraction + strings.Repeat("0", exp-len(fraction))
+	if whole == "" || strings.Trim(digits, "0123456789") != "" {
+		return 0, errors.New("invalid amount " + amount)
+	}
+	units, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+// FormatAmount formats amount in the smallest units of currency as a decimal, like "1.45" for 145 USD.
+func FormatAmount(amount int, currency string) (string, error) {
+	exp, err := currencyExponent(currency)
+	if err != nil {
+		return "", err
+	}
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	s := strconv.Itoa(amount)
+	if exp == 0 {
+		return sign + s, nil
+	}
+	if len(s) <= exp {
+		s = strings.Repeat("0", exp-len(s)+1) + s
+	}
+	return sign + s[:len(s)-exp] + "." + s[len(s)-exp:], nil
+}
@@ -0,0 +1,49 @@
+package gogram
+
+import "testing"
+
+func TestAmounts(t *testing.T) {
+	for _, c := range []struct {
+		amount   string
+		currency string
+		units    int
+	}{{"1.45", "USD", 145}, {"1.5", "eur", 150}, {"12", "USD", 1200}, {"0.05", "USD", 5}, {"500", "JPY", 500},
+		{"-2.10", "USD", -210}} {
+		units, err := ParseAmount(c.amount, c.currency)
+		if err != nil || units != c.units {
+			t.Errorf("ParseAmount(%q, %s) = %d, %v", c.amount, c.currency, units, err)
+		}
+	}
+	for _, invalid := range []string{"1.456", "", "1.a", ".5", "1..2"} {
+		if _, err := ParseAmount(invalid, "USD"); err == nil {
+			t.Errorf("ParseAmount(%q) is valid", invalid)
+		}
+	}
+	if s, _ := FormatAmount(5, "USD"); s != "0.05" {
+		t.Errorf("FormatAmount(5) = %s", s)
+	}
+	if s, _ := FormatAmount(-1450, "USD"); s != "-14.50" {
+		t.Errorf("FormatAmount(-1450) = %s", s)
+	}
+	if units, _ := ToSmallestUnits(19.99, "USD"); units != 1999 {
+		t.Errorf("ToSmallestUnits(19.99) = %d", units)
+	}
+	if _, err := FormatAmount(1, "XXX"); err == nil {
+		t.Error("unknown currency is formatted")
+	}
+}
+
+func TestSendInvoiceData_CheckTips(t *testing.T) {
+	s := SendInvoiceData{ChatId: 1, Title: "t", Description: "d", Payload: "p", ProviderToken: "token",
+		Currency: "USD", Prices: []LabeledPrice{{Label: "l", Amount: 100}}, MaxTipAmount: 500,
+		SuggestedTipAmounts: []int{100, 200, 300}}
+	if err := s.Check(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tips := range [][]int{{200, 100}, {100, 600}, {0}, {1, 2, 3, 4, 5}} {
+		s.SuggestedTipAmounts = tips
+		if err := s.Check(); err == nil {
+			t.Errorf("tips %v are valid", tips)
+		}
+	}
+}
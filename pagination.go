@@ -0,0 +1,128 @@
+package gogram
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Paginator shows a long list of buttons a page at a time, with "« Prev" and "Next »" buttons under them.
+// Send the first page with Keyboard, and pass every CallbackQuery to Handle, which edits the keyboard of the
+// message in place when a navigation button is pressed.
+//
+//	p := gogram.Paginator{Prefix: "products", PageSize: 5, Items: productButtons}
+//	k, _ := p.Keyboard(0)
+//	_ = data.SetInlineKeyboardMarkup(k)
+type Paginator struct {
+	// Prefix identifies callback data of this paginator, so several paginators can be used by one bot.
+	// It must not contain "|". This field is mandatory.
+	Prefix string
+	// PageSize is the number of items in a page. This field is mandatory.
+	PageSize int
+	// Columns is the number of items in each row. Default is 1.
+	Columns int
+	// Items returns all items of the list. It is called every time a page is rendered, so the list can change.
+	// This field is mandatory.
+	Items func() []InlineButton
+	// PrevText and NextText are labels of navigation buttons. Defaults are "« Prev" and "Next »".
+	PrevText, NextText string
+}
+
+func (p Paginator) check() error {
+	if p.Prefix == "" || strings.Contains(p.Prefix, "|") {
+		return errors.New("Prefix of Paginator must be set and must not contain |")
+	}
+	if p.PageSize < 1 {
+		return errors.New("PageSize of Paginator must be at least 1")
+	}
+	if p.Items == nil {
+		return errors.New("Items of Paginator is empty")
+	}
+	return nil
+}
+
+// Pages returns the number of pages of items. An empty list has one (empty) page.
+func (p Paginator) Pages(items int) int {
+	if items == 0 {
+		return 1
+	}
+	return (items + p.PageSize - 1) / p.PageSize
+}
+
+// callbackData returns the callback data of a button that shows page.
+func (p Paginator) callbackData(page int) string {
+	return p.Prefix + "|" + strconv.Itoa(page)
+}
+
+// Keyboard returns an inline keyboard with the items of page (0-based) and navigation buttons.
+// page is clamped to the existing pages.
+func (p Paginator) Keyboard(page int) (InlineKeyboard, error) {
+	if err := p.check(); err != nil {
+		return InlineKeyboard{}, err
+	}
+	items := p.Items()
+	pages := p.Pages(len(items))
+	if len(p.callbackData(pages)) > MaxCallbackDataLength {
+		return InlineKeyboard{}, errors.New("Prefix of Paginator is too long for callback data")
+	}
+	if page = min(page, pages-1); page < 0 {
+		page = 0
+	}
+	start := page * p.PageSize
+	columns := p.Columns
+	if columns == 0 {
+		columns = 1
+	}
+	k := InlineKeyboard{}
+	if err := k.AddGrid(columns, items[start:min(start+p.PageSize, len(items))]...); err != nil {
+		return InlineKeyboard{}, err
+	}
+	if pages == 1 {
+		return k, nil
+	}
+	prev, next := p.PrevText, p.NextText
+	if prev == "" {
+		prev = "« Prev"
+	}
+	if next == "" {
+		next = "Next »"
+	}
+	var navigation []InlineButton
+	if page > 0 {
+		navigation = append(navigation, InlineButton{Text: prev, CallbackData: p.callbackData(page - 1)})
+	}
+	// pressing the page number does nothing, since telegram doesn't accept an edit that changes nothing
+	navigation = append(navigation, InlineButton{Text: strconv.Itoa(page+1) + "/" + strconv.Itoa(pages),
+		CallbackData: p.Prefix + "|-"})
+	if page < pages-1 {
+		navigation = append(navigation, InlineButton{Text: next, CallbackData: p.callbackData(page + 1)})
+	}
+	err := k.AddRow(navigation...)
+	return k, err
+}
+
+// Handle handles q if it is sent by a navigation button of p, and returns false otherwise (so q can be
+// handled by something else). The keyboard of the message is replaced by the requested page and q is answered.
+func (p Paginator) Handle(b Bot, q CallbackQuery) (bool, error) {
+	if !strings.HasPrefix(q.Data, p.Prefix+"|") {
+		return false, nil
+	}
+	data := strings.TrimPrefix(q.Data, p.Prefix+"|")
+	if data == "-" {
+		_, err := q.Answer(b, AnswerCallbackQueryData{})
+		return true, err
+	}
+	page, err := strconv.Atoi(data)
+	if err != nil {
+		return true, errors.New("invalid page in callback data " + q.Data)
+	}
+	k, err := p.Keyboard(page)
+	if err != nil {
+		return true, err
+	}
+	if _, err = q.EditReplyMarkup(b, k); err != nil {
+		return true, err
+	}
+	_, err = q.Answer(b, AnswerCallbackQueryData{})
+	return true, err
+}
@@ -0,0 +1,48 @@
+package gogram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPaginator_Keyboard(t *testing.T) {
+	p := Paginator{Prefix: "p", PageSize: 4, Columns: 2, Items: func() []InlineButton { return buttons(10) }}
+	k, err := p.Keyboard(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := buttons(10)
+	want := [][]InlineButton{a[4:6], a[6:8], {{Text: "« Prev", CallbackData: "p|0"},
+		{Text: "2/3", CallbackData: "p|-"}, {Text: "Next »", CallbackData: "p|2"}}}
+	if !reflect.DeepEqual(k.Buttons, want) {
+		t.Errorf("got %v, want %v", k.Buttons, want)
+	}
+	// pages out of range are clamped
+	if k, _ = p.Keyboard(7); len(k.Buttons) != 2 || len(k.Buttons[1]) != 2 {
+		t.Errorf("unexpected last page %v", k.Buttons)
+	}
+}
+
+func TestPaginator_Handle(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		_, _ = w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	p := Paginator{Prefix: "p", PageSize: 4, Items: func() []InlineButton { return buttons(10) }}
+	if ok, err := p.Handle(b, CallbackQuery{Id: "1", Data: "other"}); ok || err != nil {
+		t.Errorf("foreign callback query is handled: %v %v", ok, err)
+	}
+	q := CallbackQuery{Id: "1", Data: "p|2", Message: Message{MessageId: 1, Chat: Chat{ReplyAble: ReplyAble{Id: 1}}}}
+	if ok, err := p.Handle(b, q); !ok || err != nil {
+		t.Fatalf("callback query is not handled: %v %v", ok, err)
+	}
+	if want := []string{"editMessageReplyMarkup", "answerCallbackQuery"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("got %v, want %v", methods, want)
+	}
+}
@@ -0,0 +1,409 @@
+package gogram
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ParseEntities converts a text written for parseMode (HTML, MarkdownV2 or Markdown) to plain text and
+// entities, the same way telegram does before sending a message. If parseMode is empty, text is returned as is.
+// Lengths limits of telegram (e.g. 4096 characters for texts) are applied to the plain text.
+func ParseEntities(text, parseMode string) (string, []MessageEntity, error) {
+	switch parseMode {
+	case "":
+		return text, nil, nil
+	case ParseModeHTML:
+		return parseHTML(text)
+	case ParseModeMarkdownV2:
+		return parseMarkdownV2(text)
+	case ParseModeMarkdown:
+		return parseMarkdown(text)
+	}
+	return "", nil, errors.New("parse mode must be MarkdownV2, HTML or Markdown")
+}
+
+// entityParser collects plain text and entities while a marked-up text is being parsed.
+type entityParser struct {
+	text     strings.Builder
+	offset   int
+	entities []MessageEntity
+	open     []openEntity
+}
+
+type openEntity struct {
+	// tag is the html tag or markdown delimiter that opened the entity
+	tag    string
+	entity MessageEntity
+	// index of the entity in entities. entities are reserved when they are opened, so that outer
+	// entities come before inner ones.
+	index int
+}
+
+func (p *entityParser) write(s string) {
+	p.text.WriteString(s)
+	p.offset += utf16Len(s)
+}
+
+func (p *entityParser) push(tag string, e MessageEntity) {
+	e.Offset = p.offset
+	p.open = append(p.open, openEntity{tag: tag, entity: e, index: len(p.entities)})
+	p.entities = append(p.entities, MessageEntity{})
+}
+
+// pop closes the last opened entity.
+func (p *entityParser) pop() {
+	o := p.open[len(p.open)-1]
+	p.open = p.open[:len(p.open)-1]
+	o.entity.Length = p.offset - o.entity.Offset
+	p.entities[o.index] = o.entity
+}
+
+// find returns the index of the last opened entity with tag, or -1.
+func (p *entityParser) find(tag string) int {
+	for i := len(p.open) - 1; i >= 0; i-- {
+		if p.open[i].tag == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+func (p *entityParser) result() (string, []MessageEntity, error) {
+	if len(p.open) != 0 {
+		return "", nil, errors.New("can't find end of " + p.open[len(p.open)-1].tag + " entity")
+	}
+	var entities []MessageEntity
+	for _, e := range p.entities {
+		// entities without a type (e.g. <code> inside <pre>) or length are dropped
+		if e.Type != "" && e.Length != 0 {
+			entities = append(entities, e)
+		}
+	}
+	return p.text.String(), entities, nil
+}
+
+// mentionEntity returns a text_mention entity for tg://user?id=<id> urls, and a text_link entity otherwise.
+func mentionEntity(url string) MessageEntity {
+	if strings.HasPrefix(url, "tg://user?id=") {
+		if id, err := strconv.Atoi(strings.TrimPrefix(url, "tg://user?id=")); err == nil {
+			return MessageEntity{Type: "text_mention", User: &User{ReplyAble: ReplyAble{Id: id}}}
+		}
+	}
+	return MessageEntity{Type: "text_link", Url: url}
+}
+
+var htmlTags = map[string]string{"b": "bold", "strong": "bold", "i": "italic", "em": "italic", "u": "underline",
+	"ins": "underline", "s": "strikethrough", "strike": "strikethrough", "del": "strikethrough",
+	"tg-spoiler": "spoiler", "span": "spoiler", "a": "text_link", "code": "code", "pre": "pre"}
+
+func parseHTML(text string) (string, []MessageEntity, error) {
+	p := entityParser{}
+	for i := 0; i < len(text); {
+		switch text[i] {
+		case '&':
+			end := strings.IndexByte(text[i:], ';')
+			if end == -1 {
+				p.write("&")
+				i++
+				continue
+			}
+			if s, ok := htmlEntity(text[i+1 : i+end]); ok {
+				p.write(s)
+				i += end + 1
+			} else {
+				p.write("&")
+				i++
+			}
+		case '<':
+			end := strings.IndexByte(text[i:], '>')
+			if end == -1 {
+				return "", nil, errors.New("unclosed start tag at byte offset " + strconv.Itoa(i))
+			}
+			if err := p.htmlTag(text[i+1 : i+end]); err != nil {
+				return "", nil, err
+			}
+			i += end + 1
+		default:
+			end := strings.IndexAny(text[i:], "&<")
+			if end == -1 {
+				end = len(text) - i
+			}
+			p.write(text[i : i+end])
+			i += end
+		}
+	}
+	return p.result()
+}
+
+func htmlEntity(name string) (string, bool) {
+	switch name {
+	case "lt":
+		return "<", true
+	case "gt":
+		return ">", true
+	case "amp":
+		return "&", true
+	case "quot":
+		return `"`, true
+	}
+	if strings.HasPrefix(name, "#") {
+		var n int64
+		var err error
+		if strings.HasPrefix(name, "#x") || strings.HasPrefix(name, "#X") {
+			n, err = strconv.ParseInt(name[2:], 16, 32)
+		} else {
+			n, err = strconv.ParseInt(name[1:], 10, 32)
+		}
+		if err == nil && utf8.ValidRune(rune(n)) {
+			return string(rune(n)), true
+		}
+	}
+	return "", false
+}
+
+// htmlTag handles the content of a tag, which is the text between '<' and '>'.
+func (p *entityParser) htmlTag(tag string) error {
+	if strings.HasPrefix(tag, "/") {
+		name := strings.ToLower(strings.TrimSpace(tag[1:]))
+		if len(p.open) == 0 || p.open[len(p.open)-1].tag != name {
+			return errors.New("unmatched end tag " + name)
+		}
+		p.pop()
+		return nil
+	}
+	name, attributes := tag, ""
+	if i := strings.IndexAny(tag, " \t\n"); i != -1 {
+		name, attributes = tag[:i], tag[i:]
+	}
+	name = strings.ToLower(name)
+	entityType, ok := htmlTags[name]
+	if !ok {
+		return errors.New("unsupported start tag " + name)
+	}
+	attrs := htmlAttributes(attributes)
+	e := MessageEntity{Type: entityType}
+	switch name {
+	case "span":
+		if attrs["class"] != "tg-spoiler" {
+			return errors.New("tag span must have class tg-spoiler")
+		}
+	case "a":
+		e = mentionEntity(attrs["href"])
+	case "code":
+		// <pre><code class="language-..."> sets the language of the pre entity and isn't an entity itself
+		if n := len(p.open); n != 0 && p.open[n-1].tag == "pre" && p.open[n-1].entity.Offset == p.offset {
+			p.open[n-1].entity.Language = strings.TrimPrefix(attrs["class"], "language-")
+			e.Type = ""
+		}
+	}
+	p.push(name, e)
+	return nil
+}
+
+// htmlAttributes parses attributes of a tag, e.g. ` href="https://example.com" class=x`.
+func htmlAttributes(s string) map[string]string {
+	attrs := map[string]string{}
+	for {
+		s = strings.TrimLeft(s, " \t\n")
+		eq := strings.IndexByte(s, '=')
+		if eq == -1 {
+			return attrs
+		}
+		name := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " \t\n")
+		var value string
+		if s != "" && (s[0] == '"' || s[0] == '\'') {
+			end := strings.IndexByte(s[1:], s[0])
+			if end == -1 {
+				end = len(s) - 1
+			}
+			value, s = s[1:end+1], s[min(end+2, len(s)):]
+		} else {
+			end := strings.IndexAny(s, " \t\n")
+			if end == -1 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		if unescaped, _, err := parseHTML(value); err == nil {
+			value = unescaped
+		}
+		attrs[name] = value
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+var markdownV2Entities = map[string]string{"*": "bold", "_": "italic", "__": "underline", "~": "strikethrough",
+	"||": "spoiler"}
+
+func parseMarkdownV2(text string) (string, []MessageEntity, error) {
+	p := entityParser{}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text):
+			_, size := utf8.DecodeRuneInString(text[i+1:])
+			p.write(text[i+1 : i+1+size])
+			i += 1 + size
+		case c == '\r':
+			// used to separate italic and underline delimiters; ignored by telegram
+			i++
+		case c == '`':
+			n, err := p.markdownCode(text[i:], true)
+			if err != nil {
+				return "", nil, err
+			}
+			i += n
+		case c == '[':
+			p.push("[", MessageEntity{})
+			i++
+		case c == ']' && p.find("[") != -1:
+			if p.open[len(p.open)-1].tag != "[" {
+				return "", nil, errors.New("can't find end of " + p.open[len(p.open)-1].tag + " entity")
+			}
+			if i+1 >= len(text) || text[i+1] != '(' {
+				return "", nil, errors.New("can't find url of the link")
+			}
+			url, n := markdownUrl(text[i+2:])
+			if n == -1 {
+				return "", nil, errors.New("can't find end of the url")
+			}
+			e := mentionEntity(url)
+			e.Offset = p.open[len(p.open)-1].entity.Offset
+			p.open[len(p.open)-1].entity = e
+			p.pop()
+			i += 2 + n
+		case c == '*' || c == '_' || c == '~' || c == '|' && strings.HasPrefix(text[i:], "||"):
+			tag := string(c)
+			if strings.HasPrefix(text[i:], "__") || c == '|' {
+				tag += tag
+			}
+			if j := p.find(tag); j == -1 {
+				p.push(tag, MessageEntity{Type: markdownV2Entities[tag]})
+			} else if j == len(p.open)-1 {
+				p.pop()
+			} else {
+				return "", nil, errors.New("can't find end of " + p.open[len(p.open)-1].tag + " entity")
+			}
+			i += len(tag)
+		default:
+			_, size := utf8.DecodeRuneInString(text[i:])
+			p.write(text[i : i+size])
+			i += size
+		}
+	}
+	return p.result()
+}
+
+// markdownCode parses a code or pre entity at the beginning of text and returns the number of bytes it used.
+// If escapes is true, '\' escapes '`' and '\' characters inside the entity, like MarkdownV2 does.
+func (p *entityParser) markdownCode(text string, escapes bool) (int, error) {
+	delimiter, e := "`", MessageEntity{Type: "code"}
+	if strings.HasPrefix(text, "```") {
+		delimiter, e = "```", MessageEntity{Type: "pre"}
+	}
+	i := len(delimiter)
+	if e.Type == "pre" {
+		// the first line of a pre entity is its language, if it isn't the whole entity
+		if end := strings.IndexByte(text[i:], '\n'); end != -1 && !strings.Contains(text[i:i+end], "`") {
+			e.Language = strings.TrimSpace(text[i : i+end])
+			i += end + 1
+		}
+	}
+	var content strings.Builder
+	for ; i < len(text); i++ {
+		if escapes && text[i] == '\\' && i+1 < len(text) && (text[i+1] == '`' || text[i+1] == '\\') {
+			content.WriteByte(text[i+1])
+			i++
+		} else if strings.HasPrefix(text[i:], delimiter) {
+			code := content.String()
+			if e.Type == "pre" {
+				code = strings.TrimSuffix(code, "\n")
+			}
+			p.push(delimiter, e)
+			p.write(code)
+			p.pop()
+			return i + len(delimiter), nil
+		} else {
+			content.WriteByte(text[i])
+		}
+	}
+	return 0, errors.New("can't find end of " + e.Type + " entity")
+}
+
+// markdownUrl parses the url of a link up to its closing ')' and returns the url and the number of bytes it
+// used, or -1 if the url isn't closed.
+func markdownUrl(text string) (string, int) {
+	var url strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && i+1 < len(text):
+			url.WriteByte(text[i+1])
+			i++
+		case text[i] == ')':
+			return url.String(), i + 1
+		default:
+			url.WriteByte(text[i])
+		}
+	}
+	return "", -1
+}
+
+var markdownEntities = map[byte]string{'*': "bold", '_': "italic"}
+
+// parseMarkdown parses the legacy Markdown parse mode, in which entities can't be nested.
+func parseMarkdown(text string) (string, []MessageEntity, error) {
+	p := entityParser{}
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("_*`[", text[i+1]) != -1:
+			p.write(text[i+1 : i+2])
+			i += 2
+		case c == '`':
+			n, err := p.markdownCode(text[i:], false)
+			if err != nil {
+				return "", nil, err
+			}
+			i += n
+		case c == '*' || c == '_':
+			if len(p.open) == 0 {
+				p.push(string(c), MessageEntity{Type: markdownEntities[c]})
+			} else if p.open[0].tag == string(c) {
+				p.pop()
+			} else {
+				p.write(string(c))
+			}
+			i++
+		case c == '[' && len(p.open) == 0:
+			end := strings.Index(text[i:], "](")
+			closing := -1
+			if end != -1 {
+				closing = strings.IndexByte(text[i+end:], ')')
+			}
+			if end == -1 || closing == -1 {
+				p.write("[")
+				i++
+				continue
+			}
+			e := mentionEntity(text[i+end+2 : i+end+closing])
+			p.push("[", e)
+			p.write(text[i+1 : i+end])
+			p.pop()
+			i += end + closing + 1
+		default:
+			_, size := utf8.DecodeRuneInString(text[i:])
+			p.write(text[i : i+size])
+			i += size
+		}
+	}
+	return p.result()
+}
@@ -2,9 +2,11 @@ package gogram
 
 import "errors"
 
-type PasswordData struct {
-	data        []EncryptedPassportElement
-	credentials EncryptedCredentials
+// PassportData is the data of Telegram Passport that a user shared with the bot. Decrypt Credentials with
+// DecryptCredentials, then the elements with the credentials.
+type PassportData struct {
+	Data        []EncryptedPassportElement `json:"data"`
+	Credentials EncryptedCredentials       `json:"credentials"`
 }
 
 type EncryptedCredentials struct {
@@ -49,8 +51,8 @@ type PassportBase struct {
 
 type PassportElementErrorDataField struct {
 	PassportBase
-	FieldName string `json:"field_name"`
-	DataHash  string `json:"data_hash"`
+	FieldName string `json:"field_name,omitempty"`
+	DataHash  string `json:"data_hash,omitempty"`
 }
 
 func (p PassportElementErrorDataField) checkPassport() error {
@@ -67,7 +69,7 @@ func (p PassportElementErrorDataField) checkPassport() error {
 
 type PassportElementErrorFrontSide struct {
 	PassportBase
-	FileHash string `json:"file_hash"`
+	FileHash string `json:"file_hash,omitempty"`
 }
 
 func (p PassportElementErrorFrontSide) checkPassport() error {
@@ -84,7 +86,7 @@ func (p PassportElementErrorFrontSide) checkPassport() error {
 
 type PassportElementErrorReverseSide struct {
 	PassportBase
-	FileHash string `json:"file_hash"`
+	FileHash string `json:"file_hash,omitempty"`
 }
 
 func (p PassportElementErrorReverseSide) checkPassport() error {
@@ -100,7 +102,7 @@ func (p PassportElementErrorReverseSide) checkPassport() error {
 
 type PassportElementErrorSelfie struct {
 	PassportBase
-	FileHash string `json:"file_hash"`
+	FileHash string `json:"file_hash,omitempty"`
 }
 
 func (p PassportElementErrorSelfie) checkPassport() error {
@@ -117,7 +119,7 @@ func (p PassportElementErrorSelfie) checkPassport() error {
 
 type PassportElementErrorFile struct {
 	PassportBase
-	FileHash string `json:"file_hash"`
+	FileHash string `json:"file_hash,omitempty"`
 }
 
 func (p PassportElementErrorFile) checkPassport() error {
@@ -134,7 +136,7 @@ func (p PassportElementErrorFile) checkPassport() error {
 
 type PassportElementErrorFiles struct {
 	PassportBase
-	FileHashes []string `json:"file_hashes"`
+	FileHashes []string `json:"file_hashes,omitempty"`
 }
 
 func (p PassportElementErrorFiles) checkPassport() error {
@@ -151,7 +153,7 @@ func (p PassportElementErrorFiles) checkPassport() error {
 
 type PassportElementErrorTranslationFile struct {
 	PassportBase
-	FileHash string `json:"file_hash"`
+	FileHash string `json:"file_hash,omitempty"`
 }
 
 func (p PassportElementErrorTranslationFile) checkPassport() error {
@@ -169,7 +171,7 @@ func (p PassportElementErrorTranslationFile) checkPassport() error {
 
 type PassportElementErrorTranslationFiles struct {
 	PassportBase
-	FileHashes []string `json:"file_hashes"`
+	FileHashes []string `json:"file_hashes,omitempty"`
 }
 
 func (p PassportElementErrorTranslationFiles) checkPassport() error {
@@ -187,7 +189,7 @@ func (p PassportElementErrorTranslationFiles) checkPassport() error {
 
 type PassportElementErrorUnspecified struct {
 	PassportBase
-	ElementHash string `json:"element_hash"`
+	ElementHash string `json:"element_hash,omitempty"`
 }
 
 func (p PassportElementErrorUnspecified) checkPassport() error {
@@ -197,11 +199,60 @@ func (p PassportElementErrorUnspecified) checkPassport() error {
 	return nil
 }
 
+// Constructors of passport element errors. They set Source; elementType is the Type of the element, like
+// "passport", and hashes are the base64-encoded hashes from the credentials of the element.
+
+func NewPassportElementErrorDataField(elementType, fieldName, dataHash, message string) PassportElementErrorDataField {
+	return PassportElementErrorDataField{PassportBase: PassportBase{Source: "data", Type: elementType, Message: message},
+		FieldName: fieldName, DataHash: dataHash}
+}
+
+func NewPassportElementErrorFrontSide(elementType, fileHash, message string) PassportElementErrorFrontSide {
+	return PassportElementErrorFrontSide{PassportBase: PassportBase{Source: "front_side", Type: elementType,
+		Message: message}, FileHash: fileHash}
+}
+
+func NewPassportElementErrorReverseSide(elementType, fileHash, message string) PassportElementErrorReverseSide {
+	return PassportElementErrorReverseSide{PassportBase: PassportBase{Source: "reverse_side", Type: elementType,
+		Message: message}, FileHash: fileHash}
+}
+
+func NewPassportElementErrorSelfie(elementType, fileHash, message string) PassportElementErrorSelfie {
+	return PassportElementErrorSelfie{PassportBase: PassportBase{Source: "selfie", Type: elementType,
+		Message: message}, FileHash: fileHash}
+}
+
+func NewPassportElementErrorFile(elementType, fileHash, message string) PassportElementErrorFile {
+	return PassportElementErrorFile{PassportBase: PassportBase{Source: "file", Type: elementType, Message: message},
+		FileHash: fileHash}
+}
+
+func NewPassportElementErrorFiles(elementType string, fileHashes []string, message string) PassportElementErrorFiles {
+	return PassportElementErrorFiles{PassportBase: PassportBase{Source: "files", Type: elementType, Message: message},
+		FileHashes: fileHashes}
+}
+
+func NewPassportElementErrorTranslationFile(elementType, fileHash, message string) PassportElementErrorTranslationFile {
+	return PassportElementErrorTranslationFile{PassportBase: PassportBase{Source: "translation_file",
+		Type: elementType, Message: message}, FileHash: fileHash}
+}
+
+func NewPassportElementErrorTranslationFiles(elementType string, fileHashes []string,
+	message string) PassportElementErrorTranslationFiles {
+	return PassportElementErrorTranslationFiles{PassportBase: PassportBase{Source: "translation_files",
+		Type: elementType, Message: message}, FileHashes: fileHashes}
+}
+
+func NewPassportElementErrorUnspecified(elementType, elementHash, message string) PassportElementErrorUnspecified {
+	return PassportElementErrorUnspecified{PassportBase: PassportBase{Source: "unspecified", Type: elementType,
+		Message: message}, ElementHash: elementHash}
+}
+
 type SetPassportDataErrors struct {
 	// user identifier
-	ChatId int `json:"user_id"`
+	ChatId int `json:"user_id" check:"required"`
 	// an array describing the errors
-	Errors []passport `json:"errors"`
+	Errors []passport `json:"errors" check:"required"`
 }
 
 func (s SetPassportDataErrors) Send(b Bot) (response Response, err error) {
@@ -214,5 +265,5 @@ func (s SetPassportDataErrors) Check() error {
 			return err
 		}
 	}
-	return globalEmptyFieldChecker(map[string]any{"ChatId": s.ChatId, "Errors": s.Errors})
+	return newValidator(s).err()
 }
@@ -0,0 +1,231 @@
+package gogram
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrPassportHash is returned when decrypted passport data doesn't match its hash, which means the data is
+// corrupted or the wrong secret is used.
+var ErrPassportHash = errors.New("hash of decrypted passport data doesn't match")
+
+// Credentials are the decrypted EncryptedCredentials. They hold the secrets of every element the user shared.
+type Credentials struct {
+	SecureData SecureData `json:"secure_data"`
+	// Nonce is the nonce the bot passed when requesting the data. Check it to make sure the data is requested
+	// by the bot.
+	Nonce string `json:"nonce"`
+}
+
+// SecureData holds the credentials of each element type that the user shared.
+type SecureData struct {
+	PersonalDetails       *SecureValue `json:"personal_details"`
+	Passport              *SecureValue `json:"passport"`
+	InternalPassport      *SecureValue `json:"internal_passport"`
+	DriverLicense         *SecureValue `json:"driver_license"`
+	IdentityCard          *SecureValue `json:"identity_card"`
+	Address               *SecureValue `json:"address"`
+	UtilityBill           *SecureValue `json:"utility_bill"`
+	BankStatement         *SecureValue `json:"bank_statement"`
+	RentalAgreement       *SecureValue `json:"rental_agreement"`
+	PassportRegistration  *SecureValue `json:"passport_registration"`
+	TemporaryRegistration *SecureValue `json:"temporary_registration"`
+}
+
+// Value returns the credentials of elementType, like "passport", or nil if the user didn't share it.
+func (s SecureData) Value(elementType string) *SecureValue {
+	values := map[string]*SecureValue{"personal_details": s.PersonalDetails, "passport": s.Passport,
+		"internal_passport": s.InternalPassport, "driver_license": s.DriverLicense, "identity_card": s.IdentityCard,
+		"address": s.Address, "utility_bill": s.UtilityBill, "bank_statement": s.BankStatement,
+		"rental_agreement": s.RentalAgreement, "passport_registration": s.PassportRegistration,
+		"temporary_registration": s.TemporaryRegistration}
+	return values[elementType]
+}
+
+// SecureValue holds the credentials of the data and the files of an element.
+type SecureValue struct {
+	Data        *DataCredentials  `json:"data"`
+	FrontSide   *FileCredentials  `json:"front_side"`
+	ReverseSide *FileCredentials  `json:"reverse_side"`
+	Selfie      *FileCredentials  `json:"selfie"`
+	Translation []FileCredentials `json:"translation"`
+	Files       []FileCredentials `json:"files"`
+}
+
+// DataCredentials are used to decrypt the Data field of an EncryptedPassportElement.
+type DataCredentials struct {
+	DataHash string `json:"data_hash"`
+	Secret   string `json:"secret"`
+}
+
+// FileCredentials are used to decrypt a PassportFile after it is downloaded.
+type FileCredentials struct {
+	FileHash string `json:"file_hash"`
+	Secret   string `json:"secret"`
+}
+
+// PersonalDetails is the decrypted data of a "personal_details" element.
+type PersonalDetails struct {
+	FirstName            string `json:"first_name"`
+	LastName             string `json:"last_name"`
+	MiddleName           string `json:"middle_name"`
+	BirthDate            string `json:"birth_date"`
+	Gender               string `json:"gender"`
+	CountryCode          string `json:"country_code"`
+	ResidenceCountryCode string `json:"residence_country_code"`
+	FirstNameNative      string `json:"first_name_native"`
+	LastNameNative       string `json:"last_name_native"`
+	MiddleNameNative     string `json:"middle_name_native"`
+}
+
+// ResidentialAddress is the decrypted data of an "address" element.
+type ResidentialAddress struct {
+	StreetLine1 string `json:"street_line1"`
+	StreetLine2 string `json:"street_line2"`
+	City        string `json:"city"`
+	State       string `json:"state"`
+	CountryCode string `json:"country_code"`
+	PostCode    string `json:"post_code"`
+}
+
+// IdDocumentData is the decrypted data of a "passport", "driver_license", "identity_card" or
+// "internal_passport" element. Dates are in DD.MM.YYYY format.
+type IdDocumentData struct {
+	DocumentNo string `json:"document_no"`
+	ExpiryDate string `json:"expiry_date"`
+}
+
+// DecryptCredentials decrypts the credentials with the private key of the bot, whose public key is set
+// with @BotFather.
+func (p PassportData) DecryptCredentials(key *rsa.PrivateKey) (Credentials, error) {
+	var c Credentials
+	encryptedSecret, err := base64.StdEncoding.DecodeString(p.Credentials.Secret)
+	if err != nil {
+		return c, err
+	}
+	secret, err := rsa.DecryptOAEP(sha1.New(), nil, key, encryptedSecret, nil)
+	if err != nil {
+		return c, err
+	}
+	data, err := decryptPassportBase64(p.Credentials.Data, secret, p.Credentials.Hash)
+	if err != nil {
+		return c, err
+	}
+	return c, json.Unmarshal(data, &c)
+}
+
+// Element returns the element of elementType, like "passport", and false if the user didn't share it.
+func (p PassportData) Element(elementType string) (EncryptedPassportElement, bool) {
+	for _, e := range p.Data {
+		if e.Type == elementType {
+			return e, true
+		}
+	}
+	return EncryptedPassportElement{}, false
+}
+
+// DecryptData decrypts the Data field of e with the credentials of its element type into v.
+func (e EncryptedPassportElement) DecryptData(c Credentials, v any) error {
+	value := c.SecureData.Value(e.Type)
+	if value == nil || value.Data == nil {
+		return errors.New("credentials have no data secret of " + e.Type)
+	}
+	return DecryptPassportData(e.Data, *value.Data, v)
+}
+
+// PersonalDetails decrypts the "personal_details" element.
+func (p PassportData) PersonalDetails(c Credentials) (PersonalDetails, error) {
+	var details PersonalDetails
+	return details, p.decryptElement("personal_details", c, &details)
+}
+
+// ResidentialAddress decrypts the "address" element.
+func (p PassportData) ResidentialAddress(c Credentials) (ResidentialAddress, error) {
+	var address ResidentialAddress
+	return address, p.decryptElement("address", c, &address)
+}
+
+// IdDocumentData decrypts the data of the identity document of elementType, like "passport".
+func (p PassportData) IdDocumentData(elementType string, c Credentials) (IdDocumentData, error) {
+	var document IdDocumentData
+	return document, p.decryptElement(elementType, c, &document)
+}
+
+func (p PassportData) decryptElement(elementType string, c Credentials, v any) error {
+	e, ok := p.Element(elementType)
+	if !ok {
+		return errors.New("passport data has no " + elementType + " element")
+	}
+	return e.DecryptData(c, v)
+}
+
+// DecryptPassportData decrypts data, the base64-encoded Data field of an EncryptedPassportElement, and
+// unmarshals it into v.
+func DecryptPassportData(data string, c DataCredentials, v any) error {
+	secret, err := base64.StdEncoding.DecodeString(c.Secret)
+	if err != nil {
+		return err
+	}
+	decrypted, err := decryptPassportBase64(data, secret, c.DataHash)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(decrypted, v)
+}
+
+// DecryptPassportFile decrypts the content of a downloaded PassportFile.
+func DecryptPassportFile(file []byte, c FileCredentials) ([]byte, error) {
+	secret, err := base64.StdEncoding.DecodeString(c.Secret)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := base64.StdEncoding.DecodeString(c.FileHash)
+	if err != nil {
+		return nil, err
+	}
+	return decryptPassport(file, secret, hash)
+}
+
+func decryptPassportBase64(data string, secret []byte, hash string) ([]byte, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	h, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil {
+		return nil, err
+	}
+	return decryptPassport(encrypted, secret, h)
+}
+
+// decryptPassport decrypts data with AES-256-CBC, whose key and iv are derived from SHA512(secret + hash),
+// checks that SHA256 of the result is hash, and removes the padding: random bytes at the beginning whose
+// count is the first byte.
+func decryptPassport(data, secret, hash []byte) ([]byte, error) {
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, errors.New("length of encrypted passport data must be a multiple of 16")
+	}
+	digest := sha512.Sum512(append(append([]byte{}, secret...), hash...))
+	block, err := aes.NewCipher(digest[:32])
+	if err != nil {
+		return nil, err
+	}
+	decrypted := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, digest[32:48]).CryptBlocks(decrypted, data)
+	if sum := sha256.Sum256(decrypted); !bytes.Equal(sum[:], hash) {
+		return nil, ErrPassportHash
+	}
+	padding := int(decrypted[0])
+	if padding < 32 || padding > len(decrypted) {
+		return nil, errors.New("padding of passport data is invalid")
+	}
+	return decrypted[padding:], nil
+}
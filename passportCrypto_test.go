@@ -0,0 +1,73 @@
+package gogram
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+// encryptPassport encrypts data the way telegram does, and returns the encrypted data and its hash.
+func encryptPassport(t *testing.T, data, secret []byte) ([]byte, []byte) {
+	padding := 32 + (16-(len(data)+32)%16)%16
+	padded := append(make([]byte, padding), data...)
+	padded[0] = byte(padding)
+	sum := sha256.Sum256(padded)
+	digest := sha512.Sum512(append(append([]byte{}, secret...), sum[:]...))
+	block, err := aes.NewCipher(digest[:32])
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, digest[32:48]).CryptBlocks(encrypted, padded)
+	return encrypted, sum[:]
+}
+
+func TestPassportData_Decrypt(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b64 := base64.StdEncoding.EncodeToString
+	dataSecret, credentialsSecret := []byte("0123456789abcdef0123456789abcdef"), []byte("fedcba9876543210fedcba9876543210")
+	data, dataHash := encryptPassport(t, []byte(`{"first_name":"Ada","birth_date":"10.12.1815"}`), dataSecret)
+	credentials, credentialsHash := encryptPassport(t, []byte(`{"secure_data":{"personal_details":{"data":{`+
+		`"data_hash":"`+b64(dataHash)+`","secret":"`+b64(dataSecret)+`"}}},"nonce":"n"}`), credentialsSecret)
+	encryptedSecret, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, &key.PublicKey, credentialsSecret, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := PassportData{
+		Data:        []EncryptedPassportElement{{Type: "personal_details", Data: b64(data)}},
+		Credentials: EncryptedCredentials{Data: b64(credentials), Hash: b64(credentialsHash), Secret: b64(encryptedSecret)},
+	}
+	c, err := p.DecryptCredentials(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Nonce != "n" || c.SecureData.Value("personal_details") == nil {
+		t.Fatalf("unexpected credentials %+v", c)
+	}
+	details, err := p.PersonalDetails(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.FirstName != "Ada" || details.BirthDate != "10.12.1815" {
+		t.Errorf("unexpected personal details %+v", details)
+	}
+	if _, err = p.ResidentialAddress(c); err == nil {
+		t.Error("missing element is decrypted")
+	}
+	file, fileHash := encryptPassport(t, []byte("scan"), dataSecret)
+	file[len(file)-1] ^= 1
+	_, err = DecryptPassportFile(file, FileCredentials{FileHash: b64(fileHash), Secret: b64(dataSecret)})
+	if !errors.Is(err, ErrPassportHash) {
+		t.Errorf("expected ErrPassportHash for corrupted file, got %v", err)
+	}
+}
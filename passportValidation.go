@@ -0,0 +1,93 @@
+package gogram
+
+import (
+	"errors"
+	"time"
+)
+
+// PassportRule is what a PassportValidator requires of an element type.
+type PassportRule struct {
+	// Type is the type of the element, like "passport". This field is mandatory.
+	Type string
+	// Fields are the json names of fields of the decrypted data that must not be empty, like "document_no".
+	Fields []string
+	// NotExpired requires the "expiry_date" of the decrypted data, if it has one, to be today or later.
+	NotExpired bool
+	// Selfie requires a selfie with the document.
+	Selfie bool
+	// Translation requires a translation of the document.
+	Translation bool
+}
+
+// PassportValidator checks decrypted passport elements against Rules, and builds SetPassportDataErrors
+// that tell the user what to fix. Elements without a rule and rules without an element are ignored.
+type PassportValidator struct {
+	Rules []PassportRule
+	// Now returns the current time to check expiry dates. Default is time.Now.
+	Now func() time.Time
+}
+
+// Validate checks the elements of p, and returns the errors for userId, which are empty if p is valid.
+// It returns an error if an element can't be decrypted.
+func (v PassportValidator) Validate(userId int, p PassportData, c Credentials) (SetPassportDataErrors, error) {
+	errs := SetPassportDataErrors{ChatId: userId}
+	for _, rule := range v.Rules {
+		e, ok := p.Element(rule.Type)
+		if !ok {
+			continue
+		}
+		passportErrors, err := v.validateElement(rule, e, c)
+		if err != nil {
+			return errs, err
+		}
+		errs.Errors = append(errs.Errors, passportErrors...)
+	}
+	return errs, nil
+}
+
+func (v PassportValidator) validateElement(rule PassportRule, e EncryptedPassportElement,
+	c Credentials) ([]passport, error) {
+	var errs []passport
+	if len(rule.Fields) != 0 || rule.NotExpired {
+		value := c.SecureData.Value(e.Type)
+		if value == nil || value.Data == nil {
+			return nil, errors.New("credentials have no data secret of " + e.Type)
+		}
+		var data map[string]string
+		if err := e.DecryptData(c, &data); err != nil {
+			return nil, err
+		}
+		for _, field := range rule.Fields {
+			if data[field] == "" {
+				errs = append(errs, NewPassportElementErrorDataField(e.Type, field, value.Data.DataHash,
+					field+" is required"))
+			}
+		}
+		if expiry := data["expiry_date"]; rule.NotExpired && expiry != "" && v.expired(expiry) {
+			errs = append(errs, NewPassportElementErrorDataField(e.Type, "expiry_date", value.Data.DataHash,
+				"the document is expired"))
+		}
+	}
+	if rule.Selfie && e.Selfie == (PassportFile{}) {
+		errs = append(errs, NewPassportElementErrorUnspecified(e.Type, e.Hash, "a selfie with the document is required"))
+	}
+	if rule.Translation && len(e.Translation) == 0 {
+		errs = append(errs, NewPassportElementErrorUnspecified(e.Type, e.Hash,
+			"a translation of the document is required"))
+	}
+	return errs, nil
+}
+
+// expired reports whether date, in DD.MM.YYYY format, is before today. Invalid dates are expired.
+func (v PassportValidator) expired(date string) bool {
+	expiry, err := time.Parse("02.01.2006", date)
+	if err != nil {
+		return true
+	}
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	year, month, day := now().Date()
+	return expiry.Before(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
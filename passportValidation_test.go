@@ -0,0 +1,41 @@
+package gogram
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestPassportValidator_Validate(t *testing.T) {
+	b64 := base64.StdEncoding.EncodeToString
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	data, hash := encryptPassport(t, []byte(`{"document_no":"","expiry_date":"01.01.2020"}`), secret)
+	p := PassportData{Data: []EncryptedPassportElement{{Type: "passport", Data: b64(data), Hash: "element"}}}
+	c := Credentials{SecureData: SecureData{Passport: &SecureValue{
+		Data: &DataCredentials{DataHash: b64(hash), Secret: b64(secret)}}}}
+	v := PassportValidator{
+		Rules: []PassportRule{{Type: "passport", Fields: []string{"document_no"}, NotExpired: true, Selfie: true},
+			{Type: "address", Fields: []string{"city"}}},
+		Now: func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) },
+	}
+	errs, err := v.Validate(7, p, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs.ChatId != 7 || len(errs.Errors) != 3 {
+		t.Fatalf("unexpected errors %+v", errs)
+	}
+	field := errs.Errors[0].(PassportElementErrorDataField)
+	if field.Source != "data" || field.FieldName != "document_no" || field.DataHash != b64(hash) {
+		t.Errorf("unexpected field error %+v", field)
+	}
+	if expiry := errs.Errors[1].(PassportElementErrorDataField); expiry.FieldName != "expiry_date" {
+		t.Errorf("unexpected expiry error %+v", expiry)
+	}
+	if selfie := errs.Errors[2].(PassportElementErrorUnspecified); selfie.ElementHash != "element" {
+		t.Errorf("unexpected selfie error %+v", selfie)
+	}
+	if err = errs.Check(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,123 @@
+package gogram
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultPreCheckoutTimeout is how long PaymentHandler waits for ValidatePayload if Timeout is not set. Telegram
+// cancels the payment if a pre-checkout query is not answered within 10 seconds.
+const DefaultPreCheckoutTimeout = 8 * time.Second
+
+// PaymentStore records successful payments. Implement it with a database to keep payments after the bot
+// restarts.
+type PaymentStore interface {
+	SavePayment(userId int, payment SuccessfulPayment) error
+}
+
+// MemoryPaymentStore is a PaymentStore which keeps payments in memory.
+type MemoryPaymentStore struct {
+	mu       sync.Mutex
+	payments map[int][]SuccessfulPayment
+}
+
+func (m *MemoryPaymentStore) SavePayment(userId int, payment SuccessfulPayment) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.payments == nil {
+		m.payments = map[int][]SuccessfulPayment{}
+	}
+	m.payments[userId] = append(m.payments[userId], payment)
+	return nil
+}
+
+// Payments returns the payments of userId.
+func (m *MemoryPaymentStore) Payments(userId int) []SuccessfulPayment {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]SuccessfulPayment(nil), m.payments[userId]...)
+}
+
+// PaymentHandler completes the payment flow of invoices: it answers shipping queries with the options for the
+// address, confirms pre-checkout queries whose payload is valid, and records successful payments.
+// Pass every update to Handle.
+type PaymentHandler struct {
+	// ShippingOptions returns the shipping options for the address of q. If it returns an error, shipping is
+	// refused and the error is shown to the user. Shipping queries are only sent for invoices with IsFlexible.
+	ShippingOptions func(q ShippingQuery) ([]ShippingOptions, error)
+	// ValidatePayload checks the invoice payload of q, e.g. that the product is still in stock. If it returns
+	// an error, the payment is refused and the error is shown to the user. If it is nil, every payment
+	// is confirmed.
+	ValidatePayload func(q PreCheckoutQuery) error
+	// Store records successful payments. It is optional.
+	Store PaymentStore
+	// Timeout is how long ValidatePayload can take before the payment is refused, so the query is answered
+	// before telegram's deadline. Default is DefaultPreCheckoutTimeout.
+	Timeout time.Duration
+}
+
+// Handle handles u if it is a shipping query, a pre-checkout query or a message with a successful payment,
+// and returns false otherwise.
+func (p PaymentHandler) Handle(b Bot, u Update) (bool, error) {
+	switch {
+	case u.ShippingQuery.Id != "":
+		return true, p.handleShipping(b, u.ShippingQuery)
+	case u.PreCheckoutQuery.Id != "":
+		return true, p.handlePreCheckout(b, u.PreCheckoutQuery)
+	case u.Message.SuccessfulPayment != SuccessfulPayment{}:
+		if p.Store == nil {
+			return true, nil
+		}
+		return true, p.Store.SavePayment(u.Message.User.Id, u.Message.SuccessfulPayment)
+	}
+	return false, nil
+}
+
+func (p PaymentHandler) handleShipping(b Bot, q ShippingQuery) error {
+	data := AnswerShippingQueryData{}
+	if p.ShippingOptions == nil {
+		data.ErrorMessage = "Shipping is not available"
+	} else if options, err := p.ShippingOptions(q); err != nil {
+		data.ErrorMessage = err.Error()
+	} else {
+		data.Ok, data.ShippingOptions = true, options
+	}
+	_, err := q.Answer(b, data)
+	return err
+}
+
+func (p PaymentHandler) handlePreCheckout(b Bot, q PreCheckoutQuery) error {
+	data := AnswerPreCheckoutQuery{Ok: true}
+	if p.ValidatePayload != nil {
+		timeout := p.Timeout
+		if timeout == 0 {
+			timeout = DefaultPreCheckoutTimeout
+		}
+		result := make(chan error, 1)
+		go func() { result <- p.ValidatePayload(q) }()
+		var err error
+		select {
+		case err = <-result:
+		case <-time.After(timeout):
+			err = errors.New("The payment could not be confirmed in time, please try again")
+		}
+		if err != nil {
+			data.Ok, data.ErrorMessage = false, err.Error()
+		}
+	}
+	_, err := q.Answer(b, data)
+	return err
+}
+
+// Answer answers the shipping query. ShippingQueryId of data is set automatically.
+func (s ShippingQuery) Answer(b Bot, data AnswerShippingQueryData) (Response, error) {
+	data.ShippingQueryId = s.Id
+	return data.Send(b)
+}
+
+// Answer answers the pre-checkout query. PreCheckoutQueryId of data is set automatically.
+func (p PreCheckoutQuery) Answer(b Bot, data AnswerPreCheckoutQuery) (Response, error) {
+	data.PreCheckoutQueryId = p.Id
+	return data.Send(b)
+}
@@ -0,0 +1,54 @@
+package gogram
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPaymentHandler_Handle(t *testing.T) {
+	var contentType string
+	fields := map[string]string{}
+	server := stubServer(t, &contentType, fields)
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+	store := &MemoryPaymentStore{}
+	p := PaymentHandler{
+		ShippingOptions: func(q ShippingQuery) ([]ShippingOptions, error) {
+			if q.ShippingAddress.CountryCode != "DE" {
+				return nil, errors.New("We only ship to Germany")
+			}
+			return []ShippingOptions{{Id: "dhl", Title: "DHL", Prices: []LabeledPrice{{Label: "DHL", Amount: 499}}}}, nil
+		},
+		ValidatePayload: func(q PreCheckoutQuery) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		},
+		Store:   store,
+		Timeout: 10 * time.Millisecond,
+	}
+	u := Update{ShippingQuery: ShippingQuery{Id: "s", ShippingAddress: ShippingAddress{CountryCode: "FR"}}}
+	if handled, err := p.Handle(b, u); !handled || err != nil {
+		t.Fatal(handled, err)
+	}
+	if fields["shipping_query_id"] != "s" || fields["ok"] != "false" || fields["error_message"] != "We only ship to Germany" {
+		t.Errorf("unexpected shipping answer %v", fields)
+	}
+	if _, err := p.Handle(b, Update{PreCheckoutQuery: PreCheckoutQuery{Id: "p"}}); err != nil {
+		t.Fatal(err)
+	}
+	if fields["pre_checkout_query_id"] != "p" || fields["ok"] != "false" || fields["error_message"] == "" {
+		t.Errorf("slow validation is not refused: %v", fields)
+	}
+	payment := SuccessfulPayment{Currency: "EUR", TotalAmount: 499, InvoicePayload: "order-1"}
+	u = Update{Message: Message{MessageId: 1, User: User{ReplyAble: ReplyAble{Id: 5}}, SuccessfulPayment: payment}}
+	if handled, err := p.Handle(b, u); !handled || err != nil {
+		t.Fatal(handled, err)
+	}
+	if payments := store.Payments(5); len(payments) != 1 || payments[0] != payment {
+		t.Errorf("payment is not stored: %v", payments)
+	}
+	if handled, _ := p.Handle(b, Update{Message: Message{MessageId: 2, Text: "hi"}}); handled {
+		t.Error("text message is handled")
+	}
+}
@@ -0,0 +1,253 @@
+package gogram
+
+import (
+	"errors"
+	"reflect"
+	"unicode/utf16"
+)
+
+// Length limits of texts and captions, in characters (UTF-16 code units) after entities parsing.
+const (
+	MaxTextLength    = 4096
+	MaxCaptionLength = 1024
+)
+
+// TextPart is a part of a long text, created by SplitText.
+type TextPart struct {
+	Text     string
+	Entities []MessageEntity
+}
+
+// SplitText splits a text and its entities into parts of at most limit characters (UTF-16 code units).
+// Texts are split at paragraph boundaries if possible, then at line boundaries and then at spaces.
+// Boundaries inside an entity are avoided, but if there is no other choice, the entity is split too,
+// so that each part gets its own piece of it. limit must be at least 2, so a part can hold a character
+// that takes two code units.
+func SplitText(text string, entities []MessageEntity, limit int) ([]TextPart, error) {
+	if limit < 2 {
+		return nil, errors.New("limit of SplitText must be at least 2")
+	}
+	units := utf16.Encode([]rune(text))
+	var parts []TextPart
+	for start := 0; start < len(units); {
+		// leading spaces and new lines of a part are not shown by telegram
+		for start < len(units) && isSpace(units[start]) {
+			start++
+		}
+		if start == len(units) {
+			break
+		}
+		end := len(units)
+		if end-start > limit {
+			end = splitPoint(units, entities, start, start+limit)
+		}
+		partEnd := end
+		// and neither are trailing ones
+		for partEnd > start && isSpace(units[partEnd-1]) {
+			partEnd--
+		}
+		if partEnd > start {
+			parts = append(parts, TextPart{Text: string(utf16.Decode(units[start:partEnd])),
+				Entities: entitiesBetween(entities, start, partEnd)})
+		}
+		start = end
+	}
+	return parts, nil
+}
+
+// splitPoint returns where units must be cut to make a part of units[start:max].
+func splitPoint(units []uint16, entities []MessageEntity, start, max int) int {
+	for _, separator := range []string{"\n\n", "\n", " "} {
+		if i := lastSeparator(units, entities, start, max, separator, true); i != -1 {
+			return i
+		}
+	}
+	for _, separator := range []string{"\n\n", "\n", " "} {
+		if i := lastSeparator(units, entities, start, max, separator, false); i != -1 {
+			return i
+		}
+	}
+	// no separator at all, so the text is cut in the middle of a word, but not between a surrogate pair
+	if utf16.IsSurrogate(rune(units[max-1])) && units[max-1] < 0xdc00 {
+		return max - 1
+	}
+	return max
+}
+
+// lastSeparator returns the position right after the last separator in units[start:max], or -1.
+// If outsideEntities is true, separators inside an entity are ignored.
+func lastSeparator(units []uint16, entities []MessageEntity, start, max int, separator string,
+	outsideEntities bool) int {
+	sep := utf16.Encode([]rune(separator))
+	for i := max - len(sep); i > start; i-- {
+		match := true
+		for j := range sep {
+			if units[i+j] != sep[j] {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		cut := i + len(sep)
+		if outsideEntities && insideEntity(entities, i) {
+			continue
+		}
+		return cut
+	}
+	return -1
+}
+
+func insideEntity(entities []MessageEntity, position int) bool {
+	for _, e := range entities {
+		if e.Offset < position && position < e.Offset+e.Length {
+			return true
+		}
+	}
+	return false
+}
+
+// entitiesBetween returns entities clipped to [start, end), with offsets relative to start.
+func entitiesBetween(entities []MessageEntity, start, end int) []MessageEntity {
+	var result []MessageEntity
+	for _, e := range entities {
+		eStart, eEnd := e.Offset, e.Offset+e.Length
+		if eStart < start {
+			eStart = start
+		}
+		if eEnd > end {
+			eEnd = end
+		}
+		if eStart >= eEnd {
+			continue
+		}
+		e.Offset, e.Length = eStart-start, eEnd-eStart
+		result = append(result, e)
+	}
+	return result
+}
+
+func isSpace(u uint16) bool {
+	return u == ' ' || u == '\n' || u == '\t' || u == '\r'
+}
+
+// SendSplit sends t like Send does, but if Text is longer than MaxTextLength, it is split into several
+// messages (see SplitText) which are sent in order, each one as a reply to the previous one.
+// The first message replies to ReplyToMessageId and the keyboard is attached to the last message.
+// If ParseMode is set, the text is converted to entities first, so tags are never broken.
+// It returns all sent messages.
+func (t TextData) SendSplit(b Bot) ([]Message, error) {
+	text, entities := t.Text, t.Entities
+	if t.ParseMode != "" {
+		var err error
+		if text, entities, err = ParseEntities(t.Text, t.ParseMode); err != nil {
+			return nil, err
+		}
+	}
+	if utf16Len(text) <= MaxTextLength {
+		return sendMessages(b, t)
+	}
+	parts, err := SplitText(text, entities, MaxTextLength)
+	if err != nil {
+		return nil, err
+	}
+	var messages []Message
+	for i, part := range parts {
+		d := t
+		d.Text, d.Entities, d.ParseMode = part.Text, part.Entities, ""
+		if i != 0 {
+			d.ReplyToMessageId = messages[i-1].MessageId
+		}
+		if i != len(parts)-1 {
+			d.ReplyMarkup = nil
+		}
+		sent, err := sendMessages(b, d)
+		messages = append(messages, sent...)
+		if err != nil {
+			return messages, err
+		}
+	}
+	return messages, nil
+}
+
+// sendMessages sends a data that returns a Message on success.
+func sendMessages(b Bot, d Method) ([]Message, error) {
+	res, err := d.Send(b)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := res.getResult().(*Message)
+	if !ok {
+		return nil, errors.New("telegram didn't return a message")
+	}
+	return []Message{*m}, nil
+}
+
+// sendSplitCaption sends d, a media data with a Caption, like Send does. If the caption is longer than
+// MaxCaptionLength, the media is sent without it and the caption is sent as a reply to it with
+// TextData.SendSplit, using the same send options (like DisableNotification) as d.
+func sendSplitCaption(b Bot, d Method) ([]Message, error) {
+	v := reflect.ValueOf(d)
+	text, parseMode := v.FieldByName("Caption").String(), v.FieldByName("ParseMode").String()
+	entities, _ := v.FieldByName("CaptionEntities").Interface().([]MessageEntity)
+	if parseMode != "" {
+		var err error
+		if text, entities, err = ParseEntities(text, parseMode); err != nil {
+			return nil, err
+		}
+	}
+	overflow := utf16Len(text) > MaxCaptionLength
+	if overflow {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for _, field := range []string{"Caption", "ParseMode", "CaptionEntities"} {
+			c.FieldByName(field).Set(reflect.Zero(c.FieldByName(field).Type()))
+		}
+		d = c.Interface().(Method)
+	}
+	messages, err := sendMessages(b, d)
+	if err != nil || !overflow {
+		return messages, err
+	}
+	t := TextData{ChatId: int(v.FieldByName("ChatId").Int()), Text: text, Entities: entities,
+		ReplyToMessageId: messages[0].MessageId}
+	options := reflect.ValueOf(&t).Elem()
+	for _, option := range []string{"DisableNotification", "ProtectContent", "AllowSendingWithoutReply"} {
+		if from, to := v.FieldByName(option), options.FieldByName(option); from.IsValid() && to.IsValid() {
+			to.Set(from)
+		}
+	}
+	sent, err := t.SendSplit(b)
+	return append(messages, sent...), err
+}
+
+// SendSplit sends p like Send does, but moves a caption longer than MaxCaptionLength to follow-up messages.
+func (p PhotoData) SendSplit(b Bot) ([]Message, error) {
+	return sendSplitCaption(b, p)
+}
+
+// SendSplit sends v like Send does, but moves a caption longer than MaxCaptionLength to follow-up messages.
+func (v VideoData) SendSplit(b Bot) ([]Message, error) {
+	return sendSplitCaption(b, v)
+}
+
+// SendSplit sends a like Send does, but moves a caption longer than MaxCaptionLength to follow-up messages.
+func (a AudioData) SendSplit(b Bot) ([]Message, error) {
+	return sendSplitCaption(b, a)
+}
+
+// SendSplit sends d like Send does, but moves a caption longer than MaxCaptionLength to follow-up messages.
+func (d DocumentData) SendSplit(b Bot) ([]Message, error) {
+	return sendSplitCaption(b, d)
+}
+
+// SendSplit sends v like Send does, but moves a caption longer than MaxCaptionLength to follow-up messages.
+func (v VoiceData) SendSplit(b Bot) ([]Message, error) {
+	return sendSplitCaption(b, v)
+}
+
+// SendSplit sends a like Send does, but moves a caption longer than MaxCaptionLength to follow-up messages.
+func (a AnimationData) SendSplit(b Bot) ([]Message, error) {
+	return sendSplitCaption(b, a)
+}
@@ -0,0 +1,128 @@
+package gogram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseEntities_HTML(t *testing.T) {
+	text, entities, err := ParseEntities(`<b>a &lt;<i>b</i></b> <a href="tg://user?id=5">u</a> `+
+		`<pre><code class="language-go">x</code></pre> &#128512;<span class="tg-spoiler">s</span>`, ParseModeHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "a <b u x 😀s" {
+		t.Errorf("unexpected text %q", text)
+	}
+	want := []MessageEntity{{Type: "bold", Offset: 0, Length: 4}, {Type: "italic", Offset: 3, Length: 1},
+		{Type: "text_mention", Offset: 5, Length: 1, User: &User{ReplyAble: ReplyAble{Id: 5}}},
+		{Type: "pre", Offset: 7, Length: 1, Language: "go"}, {Type: "spoiler", Offset: 11, Length: 1}}
+	if !reflect.DeepEqual(entities, want) {
+		t.Errorf("got %+v, want %+v", entities, want)
+	}
+	if _, _, err = ParseEntities("<b>a</i>", ParseModeHTML); err == nil {
+		t.Error("mismatched tags are accepted")
+	}
+}
+
+func TestParseEntities_MarkdownV2(t *testing.T) {
+	fragments := []Fragment{Bold(Plain("b."), Italic(Plain("i"))), Plain(" "), Underline(Italic(Plain("iu"))),
+		Plain(" "), Link("https://a.b/(c)", Plain("l")), Plain(" "), Code("`"), Pre("x\ny", "go")}
+	text, entities, err := ParseEntities(MarkdownV2(fragments...), ParseModeMarkdownV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantText, wantEntities := Entities(fragments...)
+	if text != wantText {
+		t.Errorf("got %q, want %q", text, wantText)
+	}
+	if !reflect.DeepEqual(entities, wantEntities) {
+		t.Errorf("got %+v, want %+v", entities, wantEntities)
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	paragraph := strings.Repeat("word ", 10)
+	text := paragraph + "\n\n" + paragraph + "\n\n" + paragraph
+	entities := []MessageEntity{{Type: "bold", Offset: 0, Length: len(text)}}
+	parts, err := SplitText(text, entities, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 parts, got %d", len(parts))
+	}
+	if parts[0].Text != strings.TrimSpace(paragraph+"\n\n"+paragraph) {
+		t.Errorf("unexpected first part %q", parts[0].Text)
+	}
+	if parts[1].Text != strings.TrimSpace(paragraph) {
+		t.Errorf("unexpected second part %q", parts[1].Text)
+	}
+	for _, p := range parts {
+		if len(p.Entities) != 1 || p.Entities[0].Offset != 0 || p.Entities[0].Length != len(p.Text) {
+			t.Errorf("entity is not split correctly: %+v", p.Entities)
+		}
+	}
+}
+
+func TestSplitText_AvoidsEntities(t *testing.T) {
+	text := "aaaa bbbb cccc"
+	parts, err := SplitText(text, []MessageEntity{{Type: "code", Offset: 5, Length: 9}}, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 || parts[0].Text != "aaaa" || parts[1].Text != "bbbb cccc" {
+		t.Fatalf("unexpected parts %+v", parts)
+	}
+	if want := []MessageEntity{{Type: "code", Offset: 0, Length: 9}}; !reflect.DeepEqual(parts[1].Entities, want) {
+		t.Errorf("got %+v, want %+v", parts[1].Entities, want)
+	}
+}
+
+func TestSplitText_SmallLimits(t *testing.T) {
+	for _, limit := range []int{-1, 0, 1} {
+		if _, err := SplitText("😀😀", nil, limit); err == nil {
+			t.Errorf("limit %d is accepted", limit)
+		}
+	}
+	parts, err := SplitText("😀😀", nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 || parts[0].Text != "😀" || parts[1].Text != "😀" {
+		t.Errorf("unexpected parts %+v", parts)
+	}
+}
+
+func TestPhotoData_SendSplit(t *testing.T) {
+	var requests []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{"method": r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, body)
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":` + strconv.Itoa(len(requests)) + `}}`))
+	}))
+	defer server.Close()
+	p := PhotoData{Photo: "file_id", ChatId: 1, Caption: strings.Repeat("a ", MaxCaptionLength),
+		DisableNotification: true, AllowSendingWithoutReply: true}
+	messages, err := p.SendSplit(Bot{Token: "token", Server: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || len(requests) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	if requests[0]["method"] != "sendPhoto" || requests[0]["caption"] != nil {
+		t.Errorf("photo is sent with its caption: %v", requests[0])
+	}
+	text := requests[1]
+	if text["method"] != "sendMessage" || text["reply_to_message_id"] != 1.0 ||
+		text["disable_notification"] != true || text["allow_sending_without_reply"] != true {
+		t.Errorf("caption is not sent with the options of the photo: %v", text)
+	}
+}
@@ -11,11 +11,51 @@ import (
 
 // Update from webhook
 type Update struct {
-	UpdateId      int           `json:"update_id"`
-	Message       Message       `json:"message"`
-	InlineQuery   InlineQuery   `json:"inline_query"`
-	CallbackQuery CallbackQuery `json:"callback_query"`
-	Poll          Poll          `json:"poll"`
+	UpdateId           int                `json:"update_id"`
+	Message            Message            `json:"message"`
+	InlineQuery        InlineQuery        `json:"inline_query"`
+	ChosenInlineResult ChosenInlineResult `json:"chosen_inline_result"`
+	CallbackQuery      CallbackQuery      `json:"callback_query"`
+	ShippingQuery      ShippingQuery      `json:"shipping_query"`
+	PreCheckoutQuery   PreCheckoutQuery   `json:"pre_checkout_query"`
+	Poll               Poll               `json:"poll"`
+	ChatJoinRequest    ChatJoinRequest    `json:"chat_join_request"`
+}
+
+const (
+	UpdateTypeMessage            = "Message"
+	UpdateTypeInlineQuery        = "InlineQuery"
+	UpdateTypeChosenInlineResult = "ChosenInlineResult"
+	UpdateTypeCallbackQuery      = "CallbackQuery"
+	UpdateTypeShippingQuery      = "ShippingQuery"
+	UpdateTypePreCheckoutQuery   = "PreCheckoutQuery"
+	UpdateTypePoll               = "Poll"
+	UpdateTypeChatJoinRequest    = "ChatJoinRequest"
+	UpdateTypeUnknown            = "Unknown"
+)
+
+// TypeIndicator function returns the type of update, so handlers can route it to the right function.
+func (u Update) TypeIndicator() string {
+	switch {
+	case u.Message.MessageId != 0:
+		return UpdateTypeMessage
+	case u.InlineQuery.Id != "":
+		return UpdateTypeInlineQuery
+	case u.ChosenInlineResult.ResultId != "":
+		return UpdateTypeChosenInlineResult
+	case u.CallbackQuery.Id != "":
+		return UpdateTypeCallbackQuery
+	case u.ShippingQuery.Id != "":
+		return UpdateTypeShippingQuery
+	case u.PreCheckoutQuery.Id != "":
+		return UpdateTypePreCheckoutQuery
+	case u.Poll.Id != "":
+		return UpdateTypePoll
+	case u.ChatJoinRequest.Date != 0:
+		return UpdateTypeChatJoinRequest
+	default:
+		return UpdateTypeUnknown
+	}
 }
 
 func (u Update) String() string {
@@ -70,7 +110,7 @@ type Message struct {
 	MigrateToChatId       int               `json:"migrate_to_chat_id"`
 	MigrateFromChatId     int               `json:"migrate_from_chat_id"`
 	PinnedMessage         *Message          `json:"pinned_message"`
-	PassportData          PasswordData      `json:"passport_data"`
+	PassportData          PassportData      `json:"passport_data"`
 	Invoice               Invoice           `json:"invoice"`
 	SuccessfulPayment     SuccessfulPayment `json:"successful_payment"`
 	ConnectedWebsite      string            `json:"connected_website"`
@@ -171,7 +211,7 @@ func (m Message) TypeIndicator() string {
 		return TypeInvoice
 	case m.SuccessfulPayment != SuccessfulPayment{}:
 		return TypeSuccessfulPayment
-	case len(m.PassportData.data) != 0:
+	case len(m.PassportData.Data) != 0:
 		return TypePassport
 	default:
 		return TypeUnknown
@@ -181,7 +221,7 @@ func (m Message) TypeIndicator() string {
 type CallbackQuery struct {
 	Id              string  `json:"id"`
 	Message         Message `json:"message"`
-	From            User    `json:"chat"`
+	From            User    `json:"from"`
 	InlineMessageId string  `json:"inline_message_id"`
 	ChatInstance    string  `json:"chat_instance"`
 	Data            string  `json:"data"`
@@ -247,10 +287,10 @@ type Voice struct {
 type Contact struct {
 	PhoneNumber string `json:"phone_number"`
 	FirstName   string `json:"first_name"`
-	LastName    string `json:"last_name"`
-	UserId      int    `json:"user_id"`
+	LastName    string `json:"last_name,omitempty"`
+	UserId      int    `json:"user_id,omitempty"`
 	// Additional data about the contact in the form of a vCard
-	Vcard string `json:"vcard"`
+	Vcard string `json:"vcard,omitempty"`
 }
 
 type Dice struct {
@@ -297,6 +337,25 @@ type OrderInfo struct {
 	ShippingAddress ShippingAddress `json:"shipping_address"`
 }
 
+// ShippingQuery is sent when a user chooses a shipping address for an invoice with IsFlexible set.
+type ShippingQuery struct {
+	Id              string          `json:"id"`
+	From            User            `json:"from"`
+	InvoicePayload  string          `json:"invoice_payload"`
+	ShippingAddress ShippingAddress `json:"shipping_address"`
+}
+
+// PreCheckoutQuery is sent when a user confirms a payment. It must be answered within 10 seconds.
+type PreCheckoutQuery struct {
+	Id               string    `json:"id"`
+	From             User      `json:"from"`
+	Currency         string    `json:"currency"`
+	TotalAmount      int       `json:"total_amount"`
+	InvoicePayload   string    `json:"invoice_payload"`
+	ShippingOptionId string    `json:"shipping_option_id"`
+	OrderInfo        OrderInfo `json:"order_info"`
+}
+
 type SuccessfulPayment struct {
 	Currency                string    `json:"currency"`
 	TotalAmount             int       `json:"total_amount"`
@@ -307,13 +366,21 @@ type SuccessfulPayment struct {
 	ProviderPaymentChargeId string    `json:"provider_payment_charge_id"`
 }
 
+// MessageEntity represents one special entity in a text message. For example, hashtags, usernames, URLs, etc.
+// Offset and Length are measured in UTF-16 code units.
 type MessageEntity struct {
-	Type     string
-	offset   int
-	length   int
-	url      string
-	user     User
-	language string
+	// Type of the entity. Currently, can be “mention”, “hashtag”, “cashtag”, “bot_command”, “url”, “email”,
+	// “phone_number”, “bold”, “italic”, “underline”, “strikethrough”, “spoiler”, “code”, “pre”,
+	// “text_link” or “text_mention”
+	Type   string `json:"type"`
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	// Optional. For “text_link” only, url that will be opened after user taps on the text
+	Url string `json:"url,omitempty"`
+	// Optional. For “text_mention” only, the mentioned user
+	User *User `json:"user,omitempty"`
+	// Optional. For “pre” only, the programming language of the entity text
+	Language string `json:"language,omitempty"`
 }
 
 type Poll struct {
@@ -453,7 +520,8 @@ type ChatMemberRestricted struct {
 	CanSendPolls          bool   `json:"can_send_polls"`
 	CanSendOtherMessages  bool   `json:"can_send_other_messages"`
 	CanAddWebPagePreviews bool   `json:"can_add_web_page_previews"`
-	UntilDate             bool   `json:"until_date"`
+	// UntilDate is the unix time when restrictions will be lifted; 0 if the user is restricted forever.
+	UntilDate int `json:"until_date"`
 }
 
 type ChatMemberLeft struct {
@@ -462,18 +530,19 @@ type ChatMemberLeft struct {
 }
 
 type ChatMemberBanned struct {
-	Status    string `json:"status"`
-	User      User   `json:"user"`
-	UntilDate bool   `json:"until_date"`
+	Status string `json:"status"`
+	User   User   `json:"user"`
+	// UntilDate is the unix time when the user will be unbanned; 0 if the user is banned forever.
+	UntilDate int `json:"until_date"`
 }
 
 type Location struct {
 	Longitude            float64 `json:"longitude"`
 	Latitude             float64 `json:"latitude"`
-	HorizontalAccuracy   float64 `json:"horizontal_accuracy"`
-	LivePeriod           int     `json:"live_period"`
-	Heading              int     `json:"heading"`
-	ProximityAlertRadius int     `json:"proximity_alert_radius"`
+	HorizontalAccuracy   float64 `json:"horizontal_accuracy,omitempty"`
+	LivePeriod           int     `json:"live_period,omitempty"`
+	Heading              int     `json:"heading,omitempty"`
+	ProximityAlertRadius int     `json:"proximity_alert_radius,omitempty"`
 }
 
 type Venue struct {
@@ -509,11 +578,11 @@ type InputMediaPhoto struct {
 	Type string `json:"type"`
 	// Pass a file_id to send a file that exists on the Telegram servers (recommended),
 	// pass an HTTP URL for Telegram to get a file from the Internet or pass a *os.File
-	Media any `json:"media"`
+	Media any `json:"media,omitempty"`
 	// Optional. Caption of the photo to be sent, 0-1024 characters after entities parsing
-	Caption         string          `json:"caption"`
-	ParseMode       string          `json:"parse_mode"`
-	CaptionEntities []MessageEntity `json:"caption_entities"`
+	Caption         string          `json:"caption,omitempty"`
+	ParseMode       string          `json:"parse_mode,omitempty"`
+	CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
 }
 
 func (i *InputMediaPhoto) returnFile() *os.File {
@@ -531,15 +600,15 @@ type InputMediaVideo struct {
 	Type string `json:"type"`
 	// Pass a file_id to send a file that exists on the Telegram servers (recommended),
 	// pass an HTTP URL for Telegram to get a file from the Internet or pass a *os.File
-	Media any `json:"media"`
+	Media any `json:"media,omitempty"`
 	// Optional. Caption of the photo to be sent, 0-1024 characters after entities parsing
-	Caption           string          `json:"caption"`
-	ParseMode         string          `json:"parse_mode"`
-	Width             int             `json:"width"`
-	Height            int             `json:"height"`
-	Duration          int             `json:"duration"`
-	SupportsStreaming bool            `json:"supports_streaming"`
-	CaptionEntities   []MessageEntity `json:"caption_entities"`
+	Caption           string          `json:"caption,omitempty"`
+	ParseMode         string          `json:"parse_mode,omitempty"`
+	Width             int             `json:"width,omitempty"`
+	Height            int             `json:"height,omitempty"`
+	Duration          int             `json:"duration,omitempty"`
+	SupportsStreaming bool            `json:"supports_streaming,omitempty"`
+	CaptionEntities   []MessageEntity `json:"caption_entities,omitempty"`
 }
 
 func (i *InputMediaVideo) returnFile() *os.File {
@@ -557,14 +626,14 @@ type InputMediaDocument struct {
 	Type string `json:"type"`
 	// Pass a file_id to send a file that exists on the Telegram servers (recommended),
 	// pass an HTTP URL for Telegram to get a file from the Internet or pass a *os.File
-	Media any `json:"media"`
+	Media any `json:"media,omitempty"`
 	// Optional. Caption of the photo to be sent, 0-1024 characters after entities parsing
-	Caption         string          `json:"caption"`
-	ParseMode       string          `json:"parse_mode"`
-	CaptionEntities []MessageEntity `json:"caption_entities"`
+	Caption         string          `json:"caption,omitempty"`
+	ParseMode       string          `json:"parse_mode,omitempty"`
+	CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
 	// Optional. Disables automatic server-side content type detection for files uploaded using
 	// multipart/form-data. Always true, if the document is sent as part of an album.
-	DisableContentTypeDetection bool `json:"disable_content_type_detection"`
+	DisableContentTypeDetection bool `json:"disable_content_type_detection,omitempty"`
 }
 
 func (i *InputMediaDocument) returnFile() *os.File {
@@ -582,14 +651,14 @@ type InputMediaAudio struct {
 	Type string `json:"type"`
 	// Pass a file_id to send a file that exists on the Telegram servers (recommended),
 	// pass an HTTP URL for Telegram to get a file from the Internet or pass a *os.File
-	Media any `json:"media"`
+	Media any `json:"media,omitempty"`
 	// Optional. Caption of the photo to be sent, 0-1024 characters after entities parsing
-	Caption         string          `json:"caption"`
-	ParseMode       string          `json:"parse_mode"`
-	CaptionEntities []MessageEntity `json:"caption_entities"`
-	Duration        int             `json:"duration"`
-	Performer       string          `json:"performer"`
-	Tile            string          `json:"tile"`
+	Caption         string          `json:"caption,omitempty"`
+	ParseMode       string          `json:"parse_mode,omitempty"`
+	CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
+	Duration        int             `json:"duration,omitempty"`
+	Performer       string          `json:"performer,omitempty"`
+	Tile            string          `json:"tile,omitempty"`
 }
 
 func (i *InputMediaAudio) returnFile() *os.File {
@@ -607,13 +676,13 @@ type InputMediaAnimation struct {
 	Type string `json:"type"`
 	// Pass a file_id to send a file that exists on the Telegram servers (recommended),
 	// pass an HTTP URL for Telegram to get a file from the Internet or pass a *os.File
-	Media any `json:"media"`
+	Media any `json:"media,omitempty"`
 	// Optional. Caption of the photo to be sent, 0-1024 characters after entities parsing
-	Caption         string          `json:"caption"`
-	ParseMode       string          `json:"parse_mode"`
-	CaptionEntities []MessageEntity `json:"caption_entities"`
-	Width           int             `json:"width"`
-	Height          int             `json:"height"`
+	Caption         string          `json:"caption,omitempty"`
+	ParseMode       string          `json:"parse_mode,omitempty"`
+	CaptionEntities []MessageEntity `json:"caption_entities,omitempty"`
+	Width           int             `json:"width,omitempty"`
+	Height          int             `json:"height,omitempty"`
 }
 
 func (i *InputMediaAnimation) returnFile() *os.File {
@@ -643,7 +712,8 @@ func (i LoginUrl) check() error {
 // CallbackGame is a placeholder, currently holds no information. Use BotFather to set up your game
 // and set Active to true.
 type CallbackGame struct {
-	Active bool
+	// Active isn't sent to telegram; set it to true to send a callback_game button.
+	Active bool `json:"-"`
 }
 
 type KeyboardButtonPollType struct {
@@ -697,6 +767,20 @@ type ChatInviteLink struct {
 	IsRevoked   bool   `json:"is_revoked"`
 	ExpireDate  int    `json:"expire_date"`
 	MemberLimit int    `json:"member_limit"`
+	// CreatesJoinRequest is true if users joining the chat via the link need to be approved by chat administrators
+	CreatesJoinRequest      bool `json:"creates_join_request"`
+	PendingJoinRequestCount int  `json:"pending_join_request_count"`
+}
+
+// ChatJoinRequest is a request to join a chat, sent to administrators of the chat. The bot can send messages
+// to From before answering the request, e.g. to verify the user.
+type ChatJoinRequest struct {
+	Chat Chat   `json:"chat"`
+	From User   `json:"from"`
+	Date int    `json:"date"`
+	Bio  string `json:"bio"`
+	// InviteLink is the link used to send the request. It is nil if the request is sent without a link.
+	InviteLink *ChatInviteLink `json:"invite_link"`
 }
 
 type LabeledPrice struct {
@@ -862,6 +946,20 @@ func (i *InlineKeyboard) AddInlineButtons(horizontal bool, a ...InlineButton) er
 	return nil
 }
 
+func (i InlineKeyboard) check() error {
+	if err := i.checkLayout(); err != nil {
+		return err
+	}
+	for _, row := range i.Buttons {
+		for _, button := range row {
+			if err := button.check(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 type ReplyKeyboard struct {
 	Keyboard              [][]ReplyButton `json:"keyboard"`
 	OneTimeKeyboard       bool            `json:"one_time_keyboard"`
@@ -926,7 +1024,7 @@ func (t *ForceReply) SetForceReply(selective bool, inputFieldPlaceholder string)
 }
 
 type Keyboard struct {
-	ReplyMarkup any `json:"reply_markup"`
+	ReplyMarkup any `json:"reply_markup,omitempty"`
 }
 
 func (k *Keyboard) SetInlineKeyboard(horizontal bool, a ...InlineButton) error {
@@ -984,6 +1082,9 @@ func (k *Keyboard) ForceReply(selective bool, inputFieldPlaceholder string) {
 	k.ReplyMarkup = i
 }
 
+// MaxCallbackDataLength is the maximum length of InlineButton.CallbackData in bytes.
+const MaxCallbackDataLength = 64
+
 // InlineButton represents one button of an inline keyboard.
 // You must use exactly one of the optional fields.
 type InlineButton struct {
@@ -994,7 +1095,7 @@ type InlineButton struct {
 	Url      string   `json:"url"`
 	LoginUrl LoginUrl `json:"login_url"`
 	// Optional. Data to be sent in a callback query
-	// to the bot when button is pressed
+	// to the bot when button is pressed, 1-64 bytes
 	CallbackData string `json:"callback_data"`
 	// Optional. If set, pressing the button will prompt the user to select one of their chats,
 	// open that chat and insert the bot's username and the specified inline query in the input field.
@@ -1016,6 +1117,28 @@ type InlineButton struct {
 	Pay bool `json:"pay"`
 }
 
+// MarshalJSON only sends the optional field that is set, since telegram expects exactly one of them.
+func (i InlineButton) MarshalJSON() ([]byte, error) {
+	button := struct {
+		Text                         string        `json:"text"`
+		Url                          string        `json:"url,omitempty"`
+		LoginUrl                     *LoginUrl     `json:"login_url,omitempty"`
+		CallbackData                 string        `json:"callback_data,omitempty"`
+		SwitchInlineQuery            string        `json:"switch_inline_query,omitempty"`
+		SwitchInlineQueryCurrentChat string        `json:"switch_inline_query_current_chat,omitempty"`
+		CallbackGame                 *CallbackGame `json:"callback_game,omitempty"`
+		Pay                          bool          `json:"pay,omitempty"`
+	}{Text: i.Text, Url: i.Url, CallbackData: i.CallbackData, SwitchInlineQuery: i.SwitchInlineQuery,
+		SwitchInlineQueryCurrentChat: i.SwitchInlineQueryCurrentChat, Pay: i.Pay}
+	if i.LoginUrl.Url != "" {
+		button.LoginUrl = &i.LoginUrl
+	}
+	if i.CallbackGame.Active {
+		button.CallbackGame = &i.CallbackGame
+	}
+	return json.Marshal(button)
+}
+
 func (i InlineButton) check() error {
 	if i.Text == "" {
 		return errors.New("text of InlineButton is empty")
@@ -1045,6 +1168,9 @@ func (i InlineButton) check() error {
 	if notEmpty != 1 {
 		return errors.New("you must set exactly one of the optional fields of InlineButton")
 	}
+	if len(i.CallbackData) > MaxCallbackDataLength {
+		return errors.New("callback data of InlineButton must be at most 64 bytes")
+	}
 	return nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 func multipartSetter(s any, w *multipart.Writer, tag string) error {
@@ -53,111 +54,221 @@ func multipartSetter(s any, w *multipart.Writer, tag string) error {
 			}
 		}
 	default:
-		Type := reflect.TypeOf(s).Kind()
-		if Type == reflect.Slice || Type == reflect.Struct || Type == reflect.Ptr {
-			// for Keyboard, InlineKeyboard and ReplyKeyboard
-			if Type == reflect.Struct && tag == "" {
-				return structMultipartParser(j, w)
+		v := reflect.ValueOf(s)
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			if v.IsNil() {
+				// unset optional fields such as PollData.CorrectOptionId
+				return nil
 			}
-			a, err := json.Marshal(j)
-			if err != nil {
-				return err
-			}
-			if err = w.WriteField(tag, string(a)); err != nil {
-				return err
-			}
-		} else {
-			return errors.New("incompatible type: " + Type.String())
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32,
+			reflect.Float64:
+			// named types like a custom string type
+			return w.WriteField(tag, fmt.Sprint(v.Interface()))
+		case reflect.Struct, reflect.Array:
+		default:
+			return errors.New("incompatible type: " + v.Kind().String())
+		}
+		// nested structs (like InlineKeyboard), slices and maps are sent as json, as telegram expects
+		value, err := jsonValue(j)
+		if err != nil {
+			return err
+		}
+		a, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if err = w.WriteField(tag, string(a)); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func structMultipartParser(s any, w *multipart.Writer) error {
+// requestField is a field of a data that is sent to telegram.
+type requestField struct {
+	name  string
+	value any
+}
+
+// requestFields returns the fields of s that must be sent to telegram, using their json tags as names.
+// Like encoding/json, fields tagged "-" are skipped, and so are fields with the omitempty option that have
+// their zero value (or are empty slices). Fields of embedded structs without a json tag (like Keyboard) are
+// added as fields of s. Untagged fields that are not embedded, like MediaGroupData.Files, get an empty name.
+func requestFields(s any) ([]requestField, error) {
 	v := reflect.ValueOf(s)
 	if v.Kind() == reflect.Ptr {
-		return errors.New("value is a pointer")
+		return nil, errors.New("value is a pointer")
 	}
+	var fields []requestField
 	for i := 0; i < v.NumField(); i++ {
-		tag := reflect.TypeOf(s).Field(i).Tag.Get("json")
-		value := v.Field(i).Interface()
-		if err := multipartSetter(value, w, tag); err != nil {
-			return err
+		field := v.Type().Field(i)
+		name, omitEmpty := parseJsonTag(field.Tag.Get("json"))
+		if name == "-" || omitEmpty && isEmptyValue(v.Field(i)) {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded, err := requestFields(v.Field(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
 		}
+		fields = append(fields, requestField{name: name, value: v.Field(i).Interface()})
 	}
-	return nil
+	return fields, nil
 }
 
-func Request(method string, bot Bot, data Method, response Response) (Response, error) {
-	if err := data.Check(); err != nil {
-		return nil, err
+// hasFile reports whether a value of a request field is a file that must be uploaded.
+func hasFile(value any) bool {
+	switch f := value.(type) {
+	case *os.File:
+		return f != nil
+	case []*os.File:
+		for _, file := range f {
+			if file != nil {
+				return true
+			}
+		}
 	}
-	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("https://api.telegram.org/bot%s/%s", bot.Token,
-		method), nil)
-	var body = &bytes.Buffer{}
-	w := multipart.NewWriter(body)
-	if data != nil {
-		if err := structMultipartParser(data, w); err != nil {
-			return response, err
+	return false
+}
+
+// structMultipartParser adds fields of s (see requestFields) to w.
+func structMultipartParser(s any, w *multipart.Writer) error {
+	fields, err := requestFields(s)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if err = multipartSetter(f.value, w, f.name); err != nil {
+			return err
 		}
 	}
-	w.Close()
-	req.Header.Add("Content-Type", w.FormDataContentType())
-	req.Body = ioutil.NopCloser(bytes.NewReader(body.Bytes()))
-	if res, err := http.DefaultClient.Do(req); err != nil {
-		return response, err
-	} else {
-		defer res.Body.Close()
-		return response.set(res)
+	return nil
+}
+
+// structJsonParser encodes fields of s (see requestFields) as a json object. It is used instead of
+// structMultipartParser when there is no file to upload.
+func structJsonParser(s any) ([]byte, error) {
+	object, err := jsonValue(s)
+	if err != nil {
+		return nil, err
 	}
+	return json.Marshal(object)
 }
 
-// globalEmptyFieldChecker is for general cases that we want to Check if a field is empty or not.
-// it accepts a map in which keys are field names and values are checked against switch cases.
-// if a field is empty, an error in "<field name> is empty" format will be returned.
-// globalEmptyFieldChecker treats ParseMode fields differently. if a field name is ParseMode, and its type is
-// string, then its value must be either "MarkdownV2", "HTML" or "Markdown"
-func globalEmptyFieldChecker(a map[string]any) error {
-	for i, j := range a {
-		switch v := j.(type) {
-		case string:
-			if v == "" && i != "ParseMode" {
-				return errors.New(i + " is empty")
-			} else if i == "ParseMode" && v != "" {
-				if v != "MarkdownV2" && v != "HTML" && v != "Markdown" {
-					return errors.New(i + " must be MarkdownV2, HTML or Markdown")
-				}
-			}
-		case int:
-			if v == 0 {
-				return errors.New(i + " is empty")
+// jsonValue prepares v for encoding/json. Structs become objects of their request fields (see requestFields),
+// so nested values, like results of AnswerInlineQueryData, follow the same rules as fields of data: empty
+// embedded keyboards are omitted, and so are nil values. Values that implement json.Marshaler are kept as is.
+func jsonValue(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if _, ok := v.(json.Marshaler); ok {
+		return v, nil
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return jsonValue(value.Elem().Interface())
+	case reflect.Struct:
+		fields, err := requestFields(v)
+		if err != nil {
+			return nil, err
+		}
+		object := map[string]any{}
+		for _, f := range fields {
+			if f.name == "" {
+				continue
 			}
-		case float64:
-			if v == 0 {
-				return errors.New(i + " is empty")
+			if object[f.name], err = jsonValue(f.value); err != nil {
+				return nil, err
 			}
-		case bool:
-			if v == false {
-				return errors.New(i + " is false")
+			// nil values are not sent by multipartSetter either
+			if object[f.name] == nil {
+				delete(object, f.name)
 			}
-		case nil:
-			return errors.New(i + " is empty")
-		case *os.File:
-			if j == nil {
-				return errors.New(i + " is empty")
+		}
+		return object, nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil, nil
+		}
+		items := make([]any, value.Len())
+		for i := range items {
+			var err error
+			if items[i], err = jsonValue(value.Index(i).Interface()); err != nil {
+				return nil, err
 			}
-		case []*os.File:
-			if j == nil {
-				return errors.New(i + " is empty")
+		}
+		return items, nil
+	}
+	return v, nil
+}
+
+// parseJsonTag splits a json tag into the field name and whether it has the omitempty option.
+func parseJsonTag(tag string) (name string, omitEmpty bool) {
+	options := strings.Split(tag, ",")
+	for _, option := range options[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return options[0], omitEmpty
+}
+
+// DefaultServer is the Bot API server used when Bot.Server is empty.
+const DefaultServer = "https://api.telegram.org"
+
+// Request checks data and sends it to method of telegram API. data is sent as a json body, unless one of its
+// fields is a file (*os.File), in which case it is sent as multipart/form-data. Both encodings send the same
+// fields with the same values.
+func Request(method string, bot Bot, data Method, response Response) (Response, error) {
+	server := bot.Server
+	if server == "" {
+		server = DefaultServer
+	}
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/bot%s/%s", server, bot.Token, method), nil)
+	body := []byte{}
+	if data != nil {
+		if err := data.Check(); err != nil {
+			return nil, err
+		}
+		fields, err := requestFields(data)
+		if err != nil {
+			return response, err
+		}
+		upload := false
+		for _, f := range fields {
+			upload = upload || hasFile(f.value)
+		}
+		if upload {
+			buffer := &bytes.Buffer{}
+			w := multipart.NewWriter(buffer)
+			if err = structMultipartParser(data, w); err != nil {
+				return response, err
 			}
-		default:
-			Type := reflect.ValueOf(v)
-			if Type.Kind() == reflect.Slice {
-				if Type.Len() == 0 {
-					return errors.New(i + " is empty")
-				}
+			w.Close()
+			req.Header.Add("Content-Type", w.FormDataContentType())
+			body = buffer.Bytes()
+		} else {
+			if body, err = structJsonParser(data); err != nil {
+				return response, err
 			}
+			req.Header.Add("Content-Type", "application/json")
 		}
 	}
-	return nil
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if res, err := http.DefaultClient.Do(req); err != nil {
+		return response, err
+	} else {
+		defer res.Body.Close()
+		return response.set(res)
+	}
 }
@@ -0,0 +1,369 @@
+package gogram
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var UpdateGolden *bool = flag.Bool("Update", false, "rewrite golden files of multipart tests")
+
+// multipartForm encodes d like Request does, and renders the form as one "name: value" line per part.
+func multipartForm(t *testing.T, d any) string {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	if err := structMultipartParser(d, w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r := multipart.NewReader(body, w.Boundary())
+	var form strings.Builder
+	for {
+		part, err := r.NextPart()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		value, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if part.FileName() != "" {
+			value = []byte(fmt.Sprintf("<file %s, %d bytes>", filepath.Base(part.FileName()), len(value)))
+		}
+		form.WriteString(part.FormName() + ": " + string(value) + "\n")
+	}
+	return form.String()
+}
+
+func TestStructMultipartParser(t *testing.T) {
+	photo, err := os.Open(filepath.Join("testdata", "multipart", "photo.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer photo.Close()
+	zero, public := 0, false
+	text := TextData{ChatId: 1, Text: "hi"}
+	text.ReplyMarkup = InlineKeyboard{Buttons: [][]InlineButton{{{Text: "b", CallbackData: "d"}}}}
+	tests := map[string]any{
+		"text":       text,
+		"photo":      PhotoData{ChatId: 1, Photo: photo, Caption: "c"},
+		"poll":       PollData{ChatId: 1, Question: "q", Options: []string{"a", "b"}, Type: "quiz", CorrectOptionId: &zero, IsAnonymous: &public},
+		"game_score": SetGameScoreData{UserId: 1, Score: 0, InlineMessageId: "m"},
+		"contact":    ContactData{ChatId: 1, Contact: Contact{PhoneNumber: "123", FirstName: "f"}},
+	}
+	for name, d := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := multipartForm(t, d)
+			golden := filepath.Join("testdata", "multipart", name+".golden")
+			if *UpdateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+// sampleInterfaces are the values that fillStruct sets fields of interface types to.
+var sampleInterfaces = map[reflect.Type]func() any{
+	reflect.TypeOf((*InputMedia)(nil)).Elem(): func() any {
+		return &InputMediaPhoto{Type: "photo", Media: "Media", Caption: "Caption"}
+	},
+	reflect.TypeOf((*QueryAnswer)(nil)).Elem(): func() any {
+		return &InlineQueryResultArticle{Type: "article", Id: "Id", Title: "Title",
+			InputMessageContent: InputTextMessageContent{MessageText: "MessageText"}}
+	},
+	reflect.TypeOf((*BotCommandScope)(nil)).Elem(): func() any {
+		return BotCommandScopeChatMember{Type: "chat_member", ChatId: 1, UserId: 1}
+	},
+}
+
+// fillStruct sets every exported field of v, so each field of a data shows up in its encoded request. Strings
+// are set to the name of their field, and fields of type any to a file id, or to a keyboard for ReplyMarkup.
+func fillStruct(t *testing.T, v reflect.Value, file *os.File) {
+	for i := 0; i < v.NumField(); i++ {
+		if field := v.Type().Field(i); field.PkgPath == "" {
+			fillValue(t, v.Field(i), field.Name, file)
+		}
+	}
+}
+
+func fillValue(t *testing.T, v reflect.Value, name string, file *os.File) {
+	switch {
+	case v.Type() == reflect.TypeOf(file):
+		v.Set(reflect.ValueOf(file))
+		return
+	case v.Kind() == reflect.Interface && v.NumMethod() == 0:
+		if name == "ReplyMarkup" {
+			v.Set(reflect.ValueOf(InlineKeyboard{Buttons: [][]InlineButton{{{Text: "b", CallbackData: "d"}}}}))
+		} else {
+			v.Set(reflect.ValueOf(name))
+		}
+		return
+	case v.Kind() == reflect.Interface:
+		sample, ok := sampleInterfaces[v.Type()]
+		if !ok {
+			t.Fatalf("no sample value for %s of type %s", name, v.Type())
+		}
+		v.Set(reflect.ValueOf(sample()))
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		fillValue(t, v.Elem(), name, file)
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		fillValue(t, v.Index(0), name, file)
+	case reflect.Struct:
+		fillStruct(t, v, file)
+	default:
+		t.Fatalf("can't fill %s of kind %s", name, v.Kind())
+	}
+}
+
+// dataStructs returns the names of the data structs declared in data.go.
+func dataStructs(t *testing.T) []string {
+	f, err := parser.ParseFile(token.NewFileSet(), "data.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, decl := range f.Decls {
+		if g, ok := decl.(*ast.GenDecl); ok && g.Tok == token.TYPE {
+			for _, spec := range g.Specs {
+				s := spec.(*ast.TypeSpec)
+				if _, ok := s.Type.(*ast.StructType); ok && strings.HasSuffix(s.Name.Name, "Data") {
+					names = append(names, s.Name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// TestStructMultipartParser_AllData encodes every data struct with all of its fields set, so a wrong json tag
+// shows up in the golden file of the struct.
+func TestStructMultipartParser_AllData(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "multipart", "photo.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	tests := map[string]any{}
+	for _, d := range []any{
+		TextData{}, PhotoData{}, VideoData{}, AudioData{}, DocumentData{}, VoiceData{}, AnimationData{}, PollData{},
+		DiceData{}, VideoNoteData{}, LocationData{}, EditMessageLiveLocationData{}, StopMessageLiveLocationData{},
+		ContactData{}, VenueData{}, MediaGroupData{}, ForwardMessageData{}, CopyMessageData{}, DeleteMessageData{},
+		DeleteChatStickerSetData{}, SetChatStickerSetData{}, GetChatMemberData{}, GetChatMemberCountData{},
+		GetChatAdministratorsData{}, GetChatData{}, LeaveChatData{}, UnpinAllChatMessagesData{},
+		SetChatDescriptionData{}, SetChatTitleData{}, DeleteChatPhotoData{}, SetChatPhotoData{},
+		RevokeChatInviteLinkData{}, ExportChatInviteLinkData{}, SendChatActionData{}, GetFileData{},
+		UnbanChatMemberData{}, SetChatAdministratorCustomTitleData{}, SetChatPermissionsData{},
+		GetUserProfilePhotosData{}, BanChatMemberData{}, RestrictChatMemberData{}, PromoteChatMemberData{},
+		CreateChatInviteLinkData{}, EditChatInviteLinkData{}, ApproveChatJoinRequestData{},
+		DeclineChatJoinRequestData{}, PinChatMessageData{}, UnpinChatMessageData{}, AnswerCallbackQueryData{},
+		SetMyCommandsData{}, DeleteMyCommandsData{}, GetMyCommandsData{}, EditMessageTextData{},
+		EditMessageCaptionData{}, EditMessageReplyMarkupData{}, StopPollData{}, EditMessageMediaData{},
+		SetWebhookData{}, SendStickerData{}, DeleteStickerFromSetData{}, SetStickerPositionInSetData{},
+		UploadStickerFileData{}, GetStickerSetData{}, CreateNewStickerSetData{}, AddStickerToSetData{},
+		SetStickerSetThumbData{}, AnswerInlineQueryData{}, SendGameData{}, SetGameScoreData{},
+		GetGameHighScoresData{}, SendInvoiceData{}, CreateInvoiceLinkData{}, AnswerShippingQueryData{},
+	} {
+		tests[reflect.TypeOf(d).Name()] = d
+	}
+	for _, name := range dataStructs(t) {
+		if _, ok := tests[name]; !ok {
+			t.Errorf("%s has no golden test", name)
+		}
+	}
+	for name, d := range tests {
+		t.Run(name, func(t *testing.T) {
+			v := reflect.New(reflect.TypeOf(d)).Elem()
+			fillStruct(t, v, file)
+			got := multipartForm(t, v.Interface())
+			golden := filepath.Join("testdata", "multipart", "data", name+".golden")
+			if *UpdateGolden {
+				if err := os.WriteFile(golden, []byte(got), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+// stubServer is a Bot API server that records the fields of the last request, with values
+// formatted like multipartSetter does. It answers with a message, or a list of messages for sendMediaGroup.
+func stubServer(t *testing.T, contentType *string, fields map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k := range fields {
+			delete(fields, k)
+		}
+		*contentType, _, _ = mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if *contentType == "application/json" {
+			object := map[string]json.RawMessage{}
+			if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+				t.Error(err)
+			}
+			for k, v := range object {
+				var s string
+				if json.Unmarshal(v, &s) == nil {
+					fields[k] = s
+				} else {
+					fields[k] = string(v)
+				}
+			}
+		} else {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Error(err)
+			}
+			for k, v := range r.MultipartForm.Value {
+				fields[k] = v[0]
+			}
+			for k, v := range r.MultipartForm.File {
+				fields[k] = "<file " + v[0].Filename + ">"
+			}
+		}
+		if strings.HasSuffix(r.URL.Path, "/sendMediaGroup") {
+			_, _ = w.Write([]byte(`{"ok":true,"result":[{"message_id":1}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+}
+
+func TestRequest_Encodings(t *testing.T) {
+	var contentType string
+	fields := map[string]string{}
+	server := stubServer(t, &contentType, fields)
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+
+	zero := 0
+	poll := PollData{ChatId: 1, Question: "q", Options: []string{"a", "b"}, Type: "quiz", CorrectOptionId: &zero}
+	poll.ReplyMarkup = InlineKeyboard{Buttons: [][]InlineButton{{{Text: "b", Url: "https://a.b"}}}}
+	if _, err := poll.Send(b); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("expected a json body, got %s", contentType)
+	}
+	want := map[string]string{"chat_id": "1", "question": "q", "options": `["a","b"]`, "type": "quiz",
+		"correct_option_id": "0", "reply_markup": `{"inline_keyboard":[[{"text":"b","url":"https://a.b"}]]}`}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("got %v, want %v", fields, want)
+	}
+	// the same data must be sent identically as multipart/form-data
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	if err := structMultipartParser(poll, w); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range form.Value {
+		if fields[k] != v[0] {
+			t.Errorf("%s is %q in json and %q in multipart", k, fields[k], v[0])
+		}
+	}
+	if len(form.Value) != len(fields) {
+		t.Errorf("json has %d fields and multipart has %d", len(fields), len(form.Value))
+	}
+
+	photo, err := os.Open(filepath.Join("testdata", "multipart", "photo.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer photo.Close()
+	if _, err = (PhotoData{ChatId: 1, Photo: photo}).Send(b); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "multipart/form-data" {
+		t.Errorf("expected a multipart body, got %s", contentType)
+	}
+	want = map[string]string{"chat_id": "1", "photo": "<file photo.txt>"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("got %v, want %v", fields, want)
+	}
+}
+
+func TestStructJsonParser_NestedKeyboards(t *testing.T) {
+	plain := &InlineQueryResultArticle{Type: "article", Id: "1", Title: "a",
+		InputMessageContent: InputTextMessageContent{MessageText: "t"}}
+	withKeyboard := *plain
+	withKeyboard.Id = "2"
+	withKeyboard.Buttons = [][]InlineButton{{{Text: "b", CallbackData: "d"}}}
+	body, err := structJsonParser(AnswerInlineQueryData{InlineQueryId: "q", Results: []QueryAnswer{plain, &withKeyboard}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"inline_query_id":"q","results":[` +
+		`{"id":"1","input_message_content":{"message_text":"t"},"title":"a","type":"article"},` +
+		`{"id":"2","input_message_content":{"message_text":"t"},"reply_markup":{"inline_keyboard":` +
+		`[[{"text":"b","callback_data":"d"}]]},"title":"a","type":"article"}]}`
+	if string(body) != want {
+		t.Errorf("got %s, want %s", body, want)
+	}
+}
+
+func TestMediaGroupData_JsonWithoutUploads(t *testing.T) {
+	var contentType string
+	fields := map[string]string{}
+	server := stubServer(t, &contentType, fields)
+	defer server.Close()
+	b := Bot{Token: "token", Server: server.URL}
+
+	if _, err := (MediaGroupData{ChatId: 1, Media: []InputMedia{&InputMediaPhoto{Media: "file_id"},
+		&InputMediaPhoto{Media: "https://example.com/a.jpg"}}}).Send(b); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("expected a json body, got %s", contentType)
+	}
+	if _, ok := fields["media"]; !ok {
+		t.Errorf("media is not sent: %v", fields)
+	}
+}
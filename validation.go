@@ -0,0 +1,244 @@
+package gogram
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError describes one problem of a field of a data.
+type FieldError struct {
+	// Field is the name of the field in the data struct, e.g. "Text"
+	Field string
+	// JSONField is the name of the field in telegram API, e.g. "text"
+	JSONField string
+	// Reason describes the problem, e.g. "must be at most 4096 characters"
+	Reason string
+}
+
+func (f FieldError) Error() string {
+	return f.Field + " " + f.Reason
+}
+
+// ValidationError is returned by Check methods of data structs when fields of a data have invalid values.
+// Unlike telegram, which reports the first problem it finds, it lists every problem at once.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (v *ValidationError) Error() string {
+	reasons := make([]string, len(v.Errors))
+	for i, e := range v.Errors {
+		reasons[i] = e.Error()
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// validator collects problems of a data in Check methods.
+type validator struct {
+	data   reflect.Type
+	errors []FieldError
+}
+
+// newValidator returns a validator for data (a struct) which already has the problems found by
+// checking the struct tags of data fields. Fields tagged with `check:"required"` must not be empty, and fields
+// whose names end with ParseMode must be empty or a valid parse mode. Other zero values are accepted,
+// so optional fields like a score of 0 are not rejected.
+// Fields are checked in the order they are declared in, including fields of embedded structs.
+func newValidator(data any) *validator {
+	v := &validator{data: reflect.TypeOf(data)}
+	v.checkFields(reflect.ValueOf(data))
+	return v
+}
+
+func (v *validator) checkFields(value reflect.Value) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field, fieldValue := value.Type().Field(i), value.Field(i)
+		if field.Anonymous && (fieldValue.Kind() == reflect.Struct || fieldValue.Kind() == reflect.Ptr) {
+			// an embedded struct that is required but empty is reported as a whole, not field by field
+			if field.Tag.Get("check") == "required" && isEmptyValue(fieldValue) {
+				v.add(field.Name, "is empty")
+			} else {
+				v.checkFields(fieldValue)
+			}
+			continue
+		}
+		if field.Tag.Get("check") == "required" && isEmptyValue(fieldValue) {
+			v.add(field.Name, "is empty")
+		}
+		if strings.HasSuffix(field.Name, "ParseMode") && fieldValue.Kind() == reflect.String {
+			switch fieldValue.String() {
+			case "", ParseModeMarkdownV2, ParseModeHTML, ParseModeMarkdown:
+			default:
+				v.add(field.Name, "must be MarkdownV2, HTML or Markdown")
+			}
+		}
+	}
+}
+
+// isEmptyValue reports whether a required field is not set. Slices and maps are empty when they have no items.
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+// add adds a problem of field. Only the first problem of each field is kept, e.g. an empty Text
+// is not reported as too short too. field may be indexed or nested, like "Options[1]" or "Media[0].Caption".
+func (v *validator) add(field, reason string) {
+	for _, e := range v.errors {
+		if e.Field == field {
+			return
+		}
+	}
+	v.errors = append(v.errors, FieldError{Field: field, JSONField: v.jsonField(field), Reason: reason})
+}
+
+// jsonField converts a field name of the data to its name in telegram API.
+func (v *validator) jsonField(field string) string {
+	name, rest := field, ""
+	if i := strings.IndexAny(field, "[."); i != -1 {
+		name, rest = field[:i], field[i:]
+	}
+	if v.data != nil {
+		t := v.data
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if f, ok := t.FieldByName(name); ok {
+			if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+				return tag + rest
+			}
+		}
+	}
+	return field
+}
+
+// err returns a *ValidationError if any problem is found, and nil otherwise.
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errors}
+}
+
+// length checks that s has between min and max characters, measured in UTF-16 code units like telegram does.
+func (v *validator) length(field, s string, min, max int) {
+	n := utf16Len(s)
+	if n < min || n > max {
+		v.add(field, "must be "+strconv.Itoa(min)+"-"+strconv.Itoa(max)+" characters, got "+strconv.Itoa(n))
+	}
+}
+
+// textLength is like length, but measures text after entities parsing. If text can't be parsed with
+// parseMode, it is left to telegram to report the problem.
+func (v *validator) textLength(field, text, parseMode string, min, max int) {
+	plain, _, err := ParseEntities(text, parseMode)
+	if err != nil {
+		return
+	}
+	v.length(field, plain, min, max)
+}
+
+// between checks that value is between min and max.
+func (v *validator) between(field string, value, min, max int) {
+	if value < min || value > max {
+		v.add(field, "must be between "+strconv.Itoa(min)+" and "+strconv.Itoa(max)+", got "+strconv.Itoa(value))
+	}
+}
+
+// tips checks suggested tip amounts of invoices: at most 4 positive amounts in ascending order, none of which is
+// greater than maxTipAmount.
+func (v *validator) tips(maxTipAmount int, suggested []int) {
+	if maxTipAmount < 0 {
+		v.add("MaxTipAmount", "must not be negative")
+	}
+	if len(suggested) > 4 {
+		v.add("SuggestedTipAmounts", "must have at most 4 amounts, got "+strconv.Itoa(len(suggested)))
+	}
+	for i, amount := range suggested {
+		switch {
+		case amount <= 0:
+			v.add("SuggestedTipAmounts", "must be positive, got "+strconv.Itoa(amount))
+		case i > 0 && amount <= suggested[i-1]:
+			v.add("SuggestedTipAmounts", "must be in ascending order")
+		case amount > maxTipAmount:
+			v.add("SuggestedTipAmounts", "must not be greater than MaxTipAmount ("+strconv.Itoa(maxTipAmount)+
+				"), got "+strconv.Itoa(amount))
+		}
+	}
+}
+
+// location checks the coordinates and the live location fields of l. live reports whether l is a live location;
+// Heading and ProximityAlertRadius are only allowed for live locations.
+func (v *validator) location(l Location, live bool) {
+	if l.Latitude < -90 || l.Latitude > 90 {
+		v.add("Latitude", "must be between -90 and 90")
+	}
+	if l.Longitude < -180 || l.Longitude > 180 {
+		v.add("Longitude", "must be between -180 and 180")
+	}
+	if l.HorizontalAccuracy < 0 || l.HorizontalAccuracy > 1500 {
+		v.add("HorizontalAccuracy", "must be between 0 and 1500")
+	}
+	if l.LivePeriod != 0 {
+		v.between("LivePeriod", l.LivePeriod, 60, 86400)
+	}
+	if l.Heading != 0 {
+		if !live {
+			v.add("Heading", "is only allowed for live locations")
+		}
+		v.between("Heading", l.Heading, 1, 360)
+	}
+	if l.ProximityAlertRadius != 0 {
+		if !live {
+			v.add("ProximityAlertRadius", "is only allowed for live locations")
+		}
+		v.between("ProximityAlertRadius", l.ProximityAlertRadius, 1, 100000)
+	}
+}
+
+// inviteLink checks the member limit of invite links, which can't be used with join requests.
+func (v *validator) inviteLink(memberLimit int, createsJoinRequest bool) {
+	if memberLimit == 0 {
+		return
+	}
+	if createsJoinRequest {
+		v.add("MemberLimit", "can't be set when CreatesJoinRequest is true")
+	}
+	v.between("MemberLimit", memberLimit, 1, 99999)
+}
+
+// oneOf checks that value is one of values.
+func (v *validator) oneOf(field, value string, values ...string) {
+	for _, j := range values {
+		if value == j {
+			return
+		}
+	}
+	v.add(field, "must be one of "+strings.Join(values, ", ")+", got "+strconv.Quote(value))
+}
+
+// replyMarkup checks buttons of markup if it is an inline keyboard.
+func (v *validator) replyMarkup(field string, markup any) {
+	if k, ok := markup.(InlineKeyboard); ok {
+		v.inlineKeyboard(field, k)
+	}
+}
+
+func (v *validator) inlineKeyboard(field string, k InlineKeyboard) {
+	if err := k.check(); err != nil {
+		v.add(field, "has an invalid button: "+err.Error())
+	}
+}
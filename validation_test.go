@@ -0,0 +1,114 @@
+package gogram
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTextData_CheckLimits(t *testing.T) {
+	// tags don't count towards the limit
+	if err := (TextData{ChatId: 1, Text: "<b>" + strings.Repeat("a", MaxTextLength) + "</b>",
+		ParseMode: ParseModeHTML}).Check(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	d := TextData{ChatId: 1, Text: strings.Repeat("😀", MaxTextLength/2+1)}
+	d.ReplyMarkup = InlineKeyboard{Buttons: [][]InlineButton{{{Text: "b", CallbackData: strings.Repeat("d", 65)}}}}
+	err := d.Check()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	if len(validationErr.Errors) != 2 || validationErr.Errors[0].Field != "Text" ||
+		validationErr.Errors[1].Field != "ReplyMarkup" {
+		t.Errorf("unexpected errors %+v", validationErr.Errors)
+	}
+}
+
+func TestPollData_Check(t *testing.T) {
+	zero := 0
+	valid := PollData{ChatId: 1, Question: "q", Options: []string{"a", "b"}, Type: "quiz", CorrectOptionId: &zero}
+	if err := valid.Check(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	invalid := valid
+	invalid.Options, invalid.CorrectOptionId, invalid.OpenPeriod, invalid.CloseDate = []string{"a"}, nil, 1, 1
+	var validationErr *ValidationError
+	if !errors.As(invalid.Check(), &validationErr) || len(validationErr.Errors) != 4 {
+		t.Errorf("unexpected error %v", invalid.Check())
+	}
+}
+
+func TestMediaGroupData_Check(t *testing.T) {
+	if err := (MediaGroupData{ChatId: 1, Media: []InputMedia{&InputMediaPhoto{}, &InputMediaVideo{}}}).Check(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := (MediaGroupData{ChatId: 1, Media: []InputMedia{&InputMediaPhoto{}, &InputMediaAudio{}}}).Check(); err == nil {
+		t.Error("audios mixed with photos are accepted")
+	}
+	err, ok := (MediaGroupData{Media: []InputMedia{&InputMediaPhoto{}, &InputMediaVideo{}}}).Check().(*ValidationError)
+	if !ok || len(err.Errors) != 1 || err.Errors[0].JSONField != "chat_id" {
+		t.Errorf("expected an error for chat_id, got %v", err)
+	}
+}
+
+func TestNewValidator(t *testing.T) {
+	// zero values of optional fields are valid
+	if err := (SetGameScoreData{UserId: 1, ChatId: 1, MessageId: 1}).Check(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := (SetStickerPositionInSetData{Sticker: "s"}).Check(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	// every missing field is reported, in the order fields are declared in
+	err := ContactData{Contact: Contact{FirstName: "f"}}.Check()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got %v", err)
+	}
+	want := []FieldError{{Field: "ChatId", JSONField: "chat_id", Reason: "is empty"},
+		{Field: "PhoneNumber", JSONField: "phone_number", Reason: "is empty"}}
+	if !reflect.DeepEqual(validationErr.Errors, want) {
+		t.Errorf("got %+v, want %+v", validationErr.Errors, want)
+	}
+	if err.Error() != "ChatId is empty; PhoneNumber is empty" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if err = (TextData{ChatId: 1, Text: "a", ParseMode: "html"}).Check(); err == nil {
+		t.Error("invalid parse mode is accepted")
+	}
+	// a required embedded struct is reported as a whole
+	if !errors.As(SendStickerData{ChatId: 1}.Check(), &validationErr) || len(validationErr.Errors) != 1 ||
+		validationErr.Errors[0].JSONField != "sticker" {
+		t.Errorf("empty sticker is accepted: %v", validationErr)
+	}
+}
+
+func TestVenueData_Check(t *testing.T) {
+	v := VenueData{ChatId: 1, Latitude: 52.5, Longitude: 200, Title: "Museum"}
+	err, ok := v.Check().(*ValidationError)
+	if !ok || len(err.Errors) != 2 || err.Errors[0].JSONField != "address" || err.Errors[1].JSONField != "longitude" {
+		t.Fatalf("unexpected errors %v", err)
+	}
+	v.Longitude, v.Address = 13.4, "Museumsinsel"
+	if err := v.Check(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheck_ReplyMarkup(t *testing.T) {
+	k := InlineKeyboard{Buttons: [][]InlineButton{{{Text: "b", CallbackData: strings.Repeat("d", 65)}}}}
+	video := VideoNoteData{ChatId: 1, VideoNote: "file"}
+	video.ReplyMarkup = k
+	location := LocationData{ChatId: 1, Location: Location{Latitude: 1, Longitude: 1}}
+	location.ReplyMarkup = k
+	contact := ContactData{ChatId: 1, Contact: Contact{PhoneNumber: "123", FirstName: "f"}}
+	contact.ReplyMarkup = k
+	for _, d := range []Method{video, location, contact} {
+		err, ok := d.Check().(*ValidationError)
+		if !ok || len(err.Errors) != 1 || err.Errors[0].Field != "ReplyMarkup" {
+			t.Errorf("%T: expected an error for ReplyMarkup, got %v", d, err)
+		}
+	}
+}